@@ -8,13 +8,17 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	httpSwagger "github.com/swaggo/http-swagger"
 
 	"example/hello/internal/api"
+	"example/hello/internal/archive"
 	"example/hello/internal/config"
 	"example/hello/internal/database"
+	"example/hello/internal/jobs"
+	"example/hello/internal/scheduler"
 	"example/hello/internal/scraper"
 
 	_ "example/hello/docs"
@@ -49,27 +53,95 @@ func main() {
 	log.Printf("DATABASE_URL configured: %v", os.Getenv("DATABASE_URL") != "")
 	log.Printf("CHROME_PATH: %s", os.Getenv("CHROME_PATH"))
 
-	// Initialize database service
-	dbService, err := database.NewService(cfg)
+	// Initialize the fixture repository selected by DB_DRIVER. Jobs,
+	// discovery, the scheduler and the page archive need Postgres.
+	fixtures, err := database.Open(cfg)
 	if err != nil {
 		log.Printf("Warning: Failed to initialize database: %v", err)
 		log.Println("Continuing without database connection...")
-		dbService = nil
+		fixtures = nil
 	} else {
-		log.Println("Database connection established")
+		log.Printf("Database connection established (%s)", cfg.Database.Driver)
 	}
+	dbService, _ := fixtures.(*database.Service)
 
 	// Initialize scraper service
-	scraperService := scraper.NewService()
+	scraperService := scraper.NewService(cfg)
+
+	// Keep the raw HTML of every fetched page so parses can be reproduced
+	var pageDB archive.Store
+	if dbService != nil {
+		pageDB = dbService
+	}
+	if pages, err := archive.Open(cfg.Archive, pageDB); err != nil {
+		log.Printf("Warning: Page archive disabled: %v", err)
+	} else if pages != nil {
+		scraperService.SetArchiver(pages)
+		log.Printf("Archiving fetched pages (%s)", cfg.Archive.Backend)
+	}
+
+	// Start scrape job workers; jobs are persisted when the database is available
+	var jobStore jobs.Store = jobs.NewMemoryStore()
+	var jobSaver jobs.Saver
+	if dbService != nil {
+		jobStore = dbService
+	}
+	if fixtures != nil {
+		jobSaver = fixtures
+	}
+	jobQueue := jobs.NewQueue(jobStore, scraperService, jobSaver, jobs.Options{
+		Workers:   cfg.Jobs.Workers,
+		QueueSize: cfg.Jobs.QueueSize,
+	})
+	if err := jobQueue.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start scrape jobs: %v", err)
+	}
+
+	// Start recurring scrapes; their state lives in the database
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	schedulerDone := make(chan struct{})
+	close(schedulerDone)
+	if cfg.Scheduler.Enabled {
+		if dbService == nil {
+			log.Println("Warning: Scheduler needs the database, recurring scrapes are disabled")
+		} else {
+			location, err := time.LoadLocation(cfg.Scheduler.Timezone)
+			if err != nil {
+				log.Fatalf("Invalid SCHEDULER_TIMEZONE: %v", err)
+			}
+			tasks, err := scheduler.Tasks(cfg.Scheduler, scheduler.Deps{
+				Discoverer: scraperService,
+				Fixtures:   dbService,
+				Queue:      jobQueue,
+			})
+			if err != nil {
+				log.Fatalf("Invalid scheduler configuration: %v", err)
+			}
+			schedulerDone = make(chan struct{})
+			go func() {
+				defer close(schedulerDone)
+				scheduler.New(tasks, dbService, location).Run(schedulerCtx)
+			}()
+		}
+	}
 
 	// Setup API handler
-	apiHandler := api.NewHandler(scraperService, dbService)
+	apiHandler := api.NewHandler(scraperService, dbService, fixtures, jobQueue)
 
 	// Setup HTTP router
 	mux := http.NewServeMux()
 	mux.HandleFunc("/health", apiHandler.Health)
 	mux.HandleFunc("/api/scrape/xgstats", apiHandler.ScrapeXGStats)
+	mux.HandleFunc("/api/scrape/batch", apiHandler.BatchScrapeXGStats)
+	mux.HandleFunc("/api/scrape/jobs/{id}", apiHandler.GetScrapeJob)
+	mux.HandleFunc("/api/discover", apiHandler.DiscoverFixtures)
+	mux.HandleFunc("/api/discovered", apiHandler.ListDiscoveredFixtures)
 	mux.HandleFunc("/api/xgstats", apiHandler.GetXGStatFixture)
+	mux.HandleFunc("/api/fixtures", apiHandler.ListFixtures)
+	mux.HandleFunc("/api/teams/{team}/summary", apiHandler.GetTeamSummary)
+	mux.HandleFunc("/api/players/{name}/shots", apiHandler.GetPlayerShots)
+	mux.HandleFunc("/api/players/{name}/summary", apiHandler.GetPlayerSummary)
+	mux.HandleFunc("/api/table", apiHandler.GetLeagueTable)
 
 	// Swagger UI
 	mux.HandleFunc("/swagger/", httpSwagger.WrapHandler)
@@ -91,8 +163,17 @@ func main() {
 	go func() {
 		log.Printf("Server listening on %s", addr)
 		log.Println("Available endpoints:")
-		log.Println("  POST /api/scrape/xgstats   - Scrape xG shot map data from xgstat.com")
+		log.Println("  POST /api/scrape/xgstats   - Queue a scrape of xG shot map data from xgstat.com")
+		log.Println("  POST /api/scrape/batch     - Queue scrapes for a list of URLs or a whole gameweek")
+		log.Println("  GET  /api/scrape/jobs/{id} - Get the status and result of a scrape job")
+		log.Println("  POST /api/discover         - Crawl gameweek listings for match URLs")
+		log.Println("  GET  /api/discovered       - List discovered fixtures and their scrape status")
 		log.Println("  GET  /api/xgstats?id=XXX   - Get saved xG statistics by fixture ID")
+		log.Println("  GET  /api/fixtures         - List saved fixtures with filters and paging")
+		log.Println("  GET  /api/teams/{team}/summary - Get a team's goals, xG and shot totals")
+		log.Println("  GET  /api/players/{name}/shots   - List a player's shots")
+		log.Println("  GET  /api/players/{name}/summary - Get a player's shooting profile")
+		log.Println("  GET  /api/table            - League table with expected points")
 		log.Println("  GET  /health               - Health check")
 		log.Printf("  GET  /swagger/             - Swagger UI (http://%s/swagger/)\n", addr)
 
@@ -108,16 +189,22 @@ func main() {
 
 	log.Println("Shutting down server...")
 
-	if dbService != nil {
-		dbService.Close()
-	}
-
+	// Stop taking requests and let in-flight ones finish while the queue and
+	// database they use are still open
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		log.Printf("Server forced to shutdown: %v", err)
+	}
+
+	stopScheduler()
+	<-schedulerDone
+	jobQueue.Stop()
+	if fixtures != nil {
+		fixtures.Close()
 	}
+	scraperService.Close()
 
 	log.Println("Server exited")
 }
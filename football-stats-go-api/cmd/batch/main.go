@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/joho/godotenv"
+
+	"example/hello/internal/archive"
+	"example/hello/internal/config"
+	"example/hello/internal/database"
+	"example/hello/internal/jobs"
+	"example/hello/internal/scraper"
+)
+
+// batch scrapes many match pages in one run and saves each fixture.
+//
+//	go run cmd/batch/main.go -season 2025-2026 -gameweek 23
+//	go run cmd/batch/main.go -urls https://www.xgstat.com/...,https://www.xgstat.com/...
+//	go run cmd/batch/main.go -file urls.txt -concurrency 4
+func main() {
+	failed, err := run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// run performs the batch and returns the number of URLs that failed. It
+// returns instead of exiting so the browser is always shut down.
+func run() (int, error) {
+	_ = godotenv.Load()
+	cfg := config.Load()
+
+	var (
+		urlList     = flag.String("urls", "", "Comma-separated match URLs")
+		urlFile     = flag.String("file", "", "File with one match URL per line")
+		competition = flag.String("competition", scraper.DefaultCompetition, "Competition of the gameweek")
+		season      = flag.String("season", "", "Season of the gameweek, e.g. 2025-2026")
+		gameweek    = flag.Int("gameweek", 0, "Gameweek whose matches are scraped")
+		concurrency = flag.Int("concurrency", cfg.Scraper.MaxTabs, "Scrapes run at the same time")
+		strict      = flag.Bool("strict", false, "Do not save fixtures whose parse report is incomplete")
+		noSave      = flag.Bool("no-save", false, "Scrape without saving to the database")
+		jsonOutput  = flag.Bool("json", false, "Print the results as JSON")
+	)
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	scraperService := scraper.NewService(cfg)
+	defer scraperService.Close()
+
+	urls, err := collectURLs(*urlList, *urlFile)
+	if err != nil {
+		return 0, err
+	}
+	if *gameweek > 0 {
+		if len(urls) > 0 {
+			return 0, fmt.Errorf("use either -urls/-file or -gameweek, not both")
+		}
+		urls, err = scraperService.GameweekMatchURLs(ctx, *competition, *season, *gameweek)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list gameweek matches: %w", err)
+		}
+	}
+	if len(urls) == 0 {
+		return 0, fmt.Errorf("no URLs to scrape, use -urls, -file, or -season with -gameweek")
+	}
+
+	var saver jobs.Saver
+	var pageDB archive.Store
+	if !*noSave {
+		fixtures, err := database.Open(cfg)
+		if err != nil {
+			return 0, fmt.Errorf("failed to connect to database (use -no-save to skip saving): %w", err)
+		}
+		defer fixtures.Close()
+		saver = fixtures
+		// Pages are archived in the database only when it is Postgres
+		if dbService, ok := fixtures.(*database.Service); ok {
+			pageDB = dbService
+		}
+	}
+
+	if pages, err := archive.Open(cfg.Archive, pageDB); err != nil {
+		log.Printf("Warning: Page archive disabled: %v", err)
+	} else {
+		scraperService.SetArchiver(pages)
+	}
+
+	log.Printf("Scraping %d matches with concurrency %d", len(urls), *concurrency)
+	results := jobs.RunBatch(ctx, scraperService, saver, urls, *strict, *concurrency)
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == jobs.StatusFailed {
+			failed++
+		}
+	}
+
+	if *jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return failed, fmt.Errorf("failed to write results: %w", err)
+		}
+	} else {
+		for _, result := range results {
+			if result.Status == jobs.StatusFailed {
+				fmt.Printf("✗ %s\n    %s\n", result.URL, result.Error)
+				continue
+			}
+			f := result.Fixture
+			fmt.Printf("✓ %s\n    GW%d %s %d-%d %s (xG %.2f-%.2f, %d shots)\n", result.URL,
+				f.Gameweek, f.HomeTeam, f.HomeScore, f.AwayScore, f.AwayTeam,
+				f.HomeXG, f.AwayXG, len(f.HomeShots)+len(f.AwayShots))
+		}
+		fmt.Printf("\n%d succeeded, %d failed\n", len(results)-failed, failed)
+	}
+
+	return failed, nil
+}
+
+// collectURLs merges the -urls list and the lines of the -file list, skipping
+// blank lines and # comments
+func collectURLs(list, file string) ([]string, error) {
+	var urls []string
+	for _, url := range strings.Split(list, ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+
+	if file == "" {
+		return urls, nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open URL file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			urls = append(urls, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read URL file: %w", err)
+	}
+
+	return urls, nil
+}
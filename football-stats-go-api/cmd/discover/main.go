@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+
+	"example/hello/internal/archive"
+	"example/hello/internal/config"
+	"example/hello/internal/database"
+	"example/hello/internal/domain"
+	"example/hello/internal/jobs"
+	"example/hello/internal/scraper"
+)
+
+// discover crawls xgstat.com gameweek listings, stores every match it finds
+// in discovered_fixtures and can then scrape the pending ones.
+//
+//	go run cmd/discover/main.go -season 2025-2026
+//	go run cmd/discover/main.go -season 2025-2026 -from 20 -to 23 -scrape
+func main() {
+	failed, err := run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// run crawls (and optionally scrapes) and returns the number of failures. It
+// returns instead of exiting so the browser is always shut down.
+func run() (int, error) {
+	_ = godotenv.Load()
+	cfg := config.Load()
+
+	var (
+		competition = flag.String("competition", scraper.DefaultCompetition, "Competition to crawl")
+		season      = flag.String("season", "", "Season to crawl, e.g. 2025-2026")
+		from        = flag.Int("from", 1, "First gameweek to crawl")
+		to          = flag.Int("to", scraper.SeasonGameweeks, "Last gameweek to crawl")
+		scrape      = flag.Bool("scrape", false, "Scrape pending fixtures after the crawl")
+		concurrency = flag.Int("concurrency", cfg.Scraper.MaxTabs, "Scrapes run at the same time with -scrape")
+		strict      = flag.Bool("strict", false, "Do not save fixtures whose parse report is incomplete")
+	)
+	flag.Parse()
+
+	if *season == "" {
+		return 0, fmt.Errorf("-season is required")
+	}
+	if *from < 1 || *to < *from {
+		return 0, fmt.Errorf("invalid gameweek range %d-%d", *from, *to)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	dbService, err := database.NewService(cfg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer dbService.Close()
+
+	scraperService := scraper.NewService(cfg)
+	defer scraperService.Close()
+
+	if pages, err := archive.Open(cfg.Archive, dbService); err != nil {
+		log.Printf("Warning: Page archive disabled: %v", err)
+	} else {
+		scraperService.SetArchiver(pages)
+	}
+
+	result := jobs.Discover(ctx, scraperService, dbService, *competition, *season, *from, *to)
+	fmt.Printf("Crawled %d gameweeks: %d matches found, %d new\n", result.Gameweeks, result.Found, result.Added)
+	for _, msg := range result.Errors {
+		fmt.Printf("✗ %s\n", msg)
+	}
+	failed := len(result.Errors)
+
+	if !*scrape {
+		return failed, nil
+	}
+
+	urls, err := jobs.PendingURLs(ctx, dbService, domain.DiscoveryFilter{
+		Competition: *competition,
+		Season:      *season,
+	})
+	if err != nil {
+		return failed, fmt.Errorf("failed to list pending fixtures: %w", err)
+	}
+
+	log.Printf("Scraping %d pending fixtures with concurrency %d", len(urls), *concurrency)
+	for _, outcome := range jobs.RunBatch(ctx, scraperService, dbService, urls, *strict, *concurrency) {
+		if outcome.Status == jobs.StatusFailed {
+			failed++
+			fmt.Printf("✗ %s\n    %s\n", outcome.URL, outcome.Error)
+		} else {
+			fmt.Printf("✓ %s\n", outcome.URL)
+		}
+	}
+
+	return failed, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"example/hello/internal/scraper"
+)
+
+// parse re-parses saved xgstat.com or understat.com pages without a browser or
+// network access. The -url picks the parser; without one the page is read as
+// an xgstat.com page.
+//
+//	go run cmd/parse/main.go -file page.html -url https://www.xgstat.com/...
+//	go run cmd/parse/main.go -file match.html -url https://understat.com/match/26631
+//	go run cmd/parse/main.go -dir ./pages -url https://www.xgstat.com/...
+func main() {
+	var (
+		file = flag.String("file", "", "Path to a saved HTML page")
+		dir  = flag.String("dir", "", "Directory of saved pages named by scraper.FileNameForURL")
+		url  = flag.String("url", "", "Original page URL (used for the fixture ID and directory lookup)")
+	)
+	flag.Parse()
+
+	if *file == "" && (*dir == "" || *url == "") {
+		log.Fatal("Use -file, or -dir together with -url")
+	}
+
+	target := *url
+	if *file != "" {
+		target = *file
+	}
+
+	pageData, err := scraper.NewFileFetcher(*dir).Fetch(context.Background(), target)
+	if err != nil {
+		log.Fatalf("Failed to load page: %v", err)
+	}
+
+	var provider scraper.Provider = scraper.XGStatProvider{}
+	sourceURL := *url
+	if sourceURL == "" {
+		sourceURL = *file
+	} else if provider, err = scraper.DefaultRegistry().Lookup(sourceURL); err != nil {
+		log.Fatal(err)
+	}
+
+	fixture, _, err := provider.Parse(pageData, sourceURL)
+	if err != nil {
+		log.Fatalf("Failed to parse page: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(fixture); err != nil {
+		log.Fatalf("Failed to write fixture: %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"example/hello/internal/archive"
+	"example/hello/internal/config"
+	"example/hello/internal/database"
+	"example/hello/internal/domain"
+	"example/hello/internal/scraper"
+)
+
+// reparse rebuilds fixtures from archived pages with the current parser.
+//
+//	go run cmd/reparse/main.go
+//	go run cmd/reparse/main.go -since 2026-01-01 -save
+//	go run cmd/reparse/main.go -backend postgres -url https://www.xgstat.com/... -json
+func main() {
+	failed, err := run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// result is the outcome of re-parsing one archived page
+type result struct {
+	URL       string                  `json:"url"`
+	FetchedAt time.Time               `json:"fetched_at"`
+	Hash      string                  `json:"hash"`
+	Fixture   *domain.DBXGStatFixture `json:"fixture,omitempty"`
+	Report    *scraper.ParseReport    `json:"report,omitempty"`
+	Saved     bool                    `json:"saved"`
+	Error     string                  `json:"error,omitempty"`
+}
+
+// run re-parses the latest archived copy of each match page and returns the
+// number of pages that failed
+func run() (int, error) {
+	_ = godotenv.Load()
+	cfg := config.Load()
+
+	var (
+		backend    = flag.String("backend", cfg.Archive.Backend, "Archive to read: file or postgres")
+		dir        = flag.String("dir", cfg.Archive.Dir, "Archive directory for the file backend")
+		url        = flag.String("url", "", "Only re-parse this URL")
+		since      = flag.String("since", "", "Only re-parse pages fetched on or after this date (2006-01-02)")
+		save       = flag.Bool("save", false, "Save the rebuilt fixtures to the database")
+		strict     = flag.Bool("strict", false, "With -save, skip fixtures whose parse report is incomplete")
+		jsonOutput = flag.Bool("json", false, "Print the results as JSON")
+	)
+	flag.Parse()
+
+	filter := archive.Filter{URL: *url}
+	if *since != "" {
+		var err error
+		if filter.Since, err = time.Parse("2006-01-02", *since); err != nil {
+			return 0, fmt.Errorf("invalid -since date: %w", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var fixtures database.FixtureRepository
+	if *save {
+		var err error
+		fixtures, err = database.Open(cfg)
+		if err != nil {
+			return 0, fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer fixtures.Close()
+	}
+
+	// The postgres archive lives in the Postgres database whatever DB_DRIVER is
+	var pageDB archive.Store
+	if *backend == "postgres" {
+		dbService, ok := fixtures.(*database.Service)
+		if !ok {
+			var err error
+			dbService, err = database.NewService(cfg)
+			if err != nil {
+				return 0, fmt.Errorf("failed to connect to database: %w", err)
+			}
+			defer dbService.Close()
+		}
+		pageDB = dbService
+	}
+	pages, err := archive.Open(config.ArchiveConfig{Backend: *backend, Dir: *dir}, pageDB)
+	if err != nil {
+		return 0, err
+	}
+	if pages == nil {
+		return 0, fmt.Errorf("no archive to read, use -backend file or postgres")
+	}
+
+	entries, err := pages.LatestPages(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list archived pages: %w", err)
+	}
+
+	providers := scraper.DefaultRegistry()
+	results := []result{}
+	failed := 0
+	for _, entry := range entries {
+		// Listing pages are archived too
+		provider, err := providers.Lookup(entry.URL)
+		if err != nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		res := reparse(ctx, pages, provider, entry)
+		if res.Error == "" && *save && (!*strict || res.Report.Complete) {
+			if err := fixtures.SaveXGStatFixture(ctx, res.Fixture); err != nil {
+				res.Error = fmt.Sprintf("failed to save data: %v", err)
+			} else {
+				res.Saved = true
+			}
+		}
+		if res.Error != "" {
+			failed++
+		}
+		results = append(results, res)
+	}
+
+	if *jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return failed, fmt.Errorf("failed to write results: %w", err)
+		}
+		return failed, nil
+	}
+
+	for _, res := range results {
+		if res.Error != "" {
+			fmt.Printf("✗ %s (%s)\n    %s\n", res.URL, res.FetchedAt.Format(time.RFC3339), res.Error)
+			continue
+		}
+		f := res.Fixture
+		status := "complete"
+		if !res.Report.Complete {
+			status = fmt.Sprintf("incomplete: missing %v, issues %v", res.Report.FieldsMissing, res.Report.Issues)
+		}
+		fmt.Printf("✓ %s (%s)\n    GW%d %s %d-%d %s (xG %.2f-%.2f, %d shots), %s\n", res.URL, res.FetchedAt.Format(time.RFC3339),
+			f.Gameweek, f.HomeTeam, f.HomeScore, f.AwayScore, f.AwayTeam,
+			f.HomeXG, f.AwayXG, len(f.HomeShots)+len(f.AwayShots), status)
+	}
+	fmt.Printf("\n%d re-parsed, %d failed\n", len(results)-failed, failed)
+
+	return failed, nil
+}
+
+// reparse loads one archived page and parses it with its provider
+func reparse(ctx context.Context, pages archive.Store, provider scraper.Provider, entry archive.Entry) result {
+	res := result{URL: entry.URL, FetchedAt: entry.FetchedAt, Hash: entry.Hash}
+
+	html, err := pages.LoadPage(ctx, entry)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	fixture, report, err := scraper.ParseWith(provider, html, entry.URL)
+	res.Report = report
+	if err != nil {
+		res.Error = fmt.Sprintf("failed to parse xG data: %v", err)
+		return res
+	}
+	res.Fixture = fixture
+	return res
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+
+	"example/hello/internal/config"
+	"example/hello/internal/database"
+	"example/hello/internal/domain"
+	"example/hello/internal/statsbomb"
+)
+
+// statsbomb imports shots from a local copy of StatsBomb open-data.
+// Competition and season IDs are listed in data/competitions.json, e.g. 2/27
+// is the Premier League 2015/2016.
+//
+//	go run cmd/statsbomb/main.go -dir ../open-data -competition 2 -season 27
+//	go run cmd/statsbomb/main.go -dir ../open-data -competition 2 -season 27 -match 3754058 -json
+//	go run cmd/statsbomb/main.go -dir ../open-data -competition 2 -season 27 -save
+func main() {
+	failed, err := run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// result is the outcome of importing one match
+type result struct {
+	MatchID int                     `json:"match_id"`
+	Fixture *domain.DBXGStatFixture `json:"fixture,omitempty"`
+	Saved   bool                    `json:"saved"`
+	Error   string                  `json:"error,omitempty"`
+}
+
+// run imports the season's matches and returns the number that failed
+func run() (int, error) {
+	_ = godotenv.Load()
+
+	var (
+		dir           = flag.String("dir", "", "StatsBomb open-data checkout, or its data directory")
+		competitionID = flag.Int("competition", 0, "StatsBomb competition ID")
+		seasonID      = flag.Int("season", 0, "StatsBomb season ID")
+		matchID       = flag.Int("match", 0, "Only import this match")
+		save          = flag.Bool("save", false, "Save the fixtures to the database")
+		jsonOutput    = flag.Bool("json", false, "Print the results as JSON")
+	)
+	flag.Parse()
+
+	if *dir == "" || *competitionID == 0 || *seasonID == 0 {
+		return 0, fmt.Errorf("-dir, -competition and -season are required")
+	}
+
+	matches, err := statsbomb.Matches(*dir, *competitionID, *seasonID)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var fixtures database.FixtureRepository
+	if *save {
+		fixtures, err = database.Open(config.Load())
+		if err != nil {
+			return 0, fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer fixtures.Close()
+	}
+
+	results := []result{}
+	failed := 0
+	for _, match := range matches {
+		if *matchID != 0 && match.ID != *matchID {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		res := result{MatchID: match.ID}
+		fixture, err := statsbomb.Fixture(*dir, match)
+		if err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Fixture = fixture
+			if *save {
+				if err := fixtures.SaveXGStatFixture(ctx, fixture); err != nil {
+					res.Error = fmt.Sprintf("failed to save data: %v", err)
+				} else {
+					res.Saved = true
+				}
+			}
+		}
+		if res.Error != "" {
+			failed++
+		}
+		results = append(results, res)
+	}
+
+	if *matchID != 0 && len(results) == 0 {
+		return 0, fmt.Errorf("match %d is not in competition %d season %d", *matchID, *competitionID, *seasonID)
+	}
+
+	if *jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return failed, fmt.Errorf("failed to write results: %w", err)
+		}
+		return failed, nil
+	}
+
+	for _, res := range results {
+		if res.Error != "" {
+			fmt.Printf("✗ %d\n    %s\n", res.MatchID, res.Error)
+			continue
+		}
+		f := res.Fixture
+		fmt.Printf("✓ %d %s %d-%d %s (xG %.2f-%.2f, %d shots)\n", f.ID,
+			f.HomeTeam, f.HomeScore, f.AwayScore, f.AwayTeam,
+			f.HomeXG, f.AwayXG, len(f.HomeShots)+len(f.AwayShots))
+	}
+	fmt.Printf("\n%d imported, %d failed\n", len(results)-failed, failed)
+
+	return failed, nil
+}
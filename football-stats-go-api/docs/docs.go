@@ -23,9 +23,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/scrape/xgstats": {
+        "/discover": {
             "post": {
-                "description": "Scrape xG statistics and shot map data from xgstat.com",
+                "description": "Crawl the gameweek listing pages of a season and store every match found as a pending discovered fixture. Optionally queue a scrape job for each pending fixture afterwards.",
                 "consumes": [
                     "application/json"
                 ],
@@ -33,23 +33,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "scraper"
+                    "discovery"
                 ],
-                "summary": "Scrape xG shot map data",
+                "summary": "Discover fixtures",
                 "parameters": [
                     {
-                        "description": "Scrape request with xgstat.com URL",
+                        "description": "Season and gameweek range to crawl",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_api.ScrapeRequest"
+                            "$ref": "#/definitions/internal_api.DiscoverRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Scraped xG statistics and shot map data",
+                    "202": {
+                        "description": "Crawl started",
                         "schema": {
                             "allOf": [
                                 {
@@ -59,7 +59,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/example_hello_internal_domain.DBXGStatFixture"
+                                            "$ref": "#/definitions/internal_api.DiscoverRequest"
                                         }
                                     }
                                 }
@@ -77,35 +77,176 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/internal_api.Response"
                         }
+                    },
+                    "503": {
+                        "description": "Database not available",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
                     }
                 }
             }
         },
-        "/xgstats": {
+        "/discovered": {
             "get": {
-                "description": "Retrieve saved xG statistics and shot map data from the database",
-                "consumes": [
+                "description": "List fixtures found by discovery, optionally filtered by competition, season, gameweek and status",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "discovery"
+                ],
+                "summary": "List discovered fixtures",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Competition, e.g. premier-league",
+                        "name": "competition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season, e.g. 2025-2026",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Gameweek",
+                        "name": "gameweek",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, scraped or failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of fixtures",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Discovered fixtures",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/example_hello_internal_domain.DBDiscoveredFixture"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database not available",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/fixtures": {
+            "get": {
+                "description": "List saved fixtures without their shots, filtered and sorted. Pass next_cursor as cursor to get the next page.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "scraper"
+                    "fixtures"
                 ],
-                "summary": "Get xG statistics by fixture ID",
+                "summary": "List saved fixtures",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source of the fixtures: xgstat (default), understat or statsbomb",
+                        "name": "source",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Fixture ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Gameweek",
+                        "name": "gameweek",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Home or away team, ignoring case",
+                        "name": "team",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First match date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last match date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum total xG of both teams",
+                        "name": "min_xg",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum total xG of both teams",
+                        "name": "max_xg",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "home, away or draw; win or loss for the team",
+                        "name": "result",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "date, gameweek or xg, prefixed with - for descending order (default -date)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Fixtures per page (default 50, at most 200)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Retrieved xG statistics",
+                        "description": "Fixtures",
                         "schema": {
                             "allOf": [
                                 {
@@ -115,7 +256,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/example_hello_internal_domain.DBXGStatFixture"
+                                            "$ref": "#/definitions/example_hello_internal_domain.FixturePage"
                                         }
                                     }
                                 }
@@ -128,92 +269,1374 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_api.Response"
                         }
                     },
-                    "404": {
-                        "description": "Fixture not found",
+                    "503": {
+                        "description": "Database not available",
                         "schema": {
                             "$ref": "#/definitions/internal_api.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "example_hello_internal_domain.DBXGStatFixture": {
-            "type": "object",
-            "properties": {
-                "away_score": {
-                    "type": "integer"
-                },
-                "away_shots": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/example_hello_internal_domain.DBXGStatShot"
+        },
+        "/players/{name}/shots": {
+            "get": {
+                "description": "Every saved shot of a player, with the fixture it was taken in, in match order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "List a player's shots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Player name, as stored with the shots",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Source of the fixtures: understat (default) or statsbomb; xgstat shots have no player, so xgstat is a 400",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team the player shot for, ignoring case",
+                        "name": "team",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First match date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last match date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
                     }
-                },
-                "away_team": {
-                    "type": "string"
-                },
-                "away_xg": {
-                    "type": "number"
-                },
-                "date": {
-                    "type": "string"
-                },
-                "gameweek": {
-                    "type": "integer"
-                },
-                "home_score": {
-                    "type": "integer"
-                },
-                "home_shots": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/example_hello_internal_domain.DBXGStatShot"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shots",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/example_hello_internal_domain.PlayerShot"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database not available",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
                     }
-                },
-                "home_team": {
-                    "type": "string"
-                },
-                "home_xg": {
-                    "type": "number"
-                },
-                "id": {
-                    "type": "integer"
                 }
             }
         },
-        "example_hello_internal_domain.DBXGStatShot": {
-            "type": "object",
-            "properties": {
-                "is_goal": {
-                    "type": "boolean"
-                },
-                "minute": {
-                    "type": "integer"
-                },
-                "player_name": {
-                    "type": "string"
-                },
-                "shot_type": {
-                    "type": "string"
-                },
-                "x": {
-                    "type": "number"
-                },
-                "xg": {
-                    "type": "number"
-                },
-                "y": {
-                    "type": "number"
+        "/players/{name}/summary": {
+            "get": {
+                "description": "Total xG, goals, goals minus xG, shots per match, average shot distance and a breakdown by shot type",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Get a player's shooting profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Player name, as stored with the shots",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Source of the fixtures: understat (default) or statsbomb; xgstat shots have no player, so xgstat is a 400",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team the player shot for, ignoring case",
+                        "name": "team",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First match date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last match date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shooting profile",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/example_hello_internal_domain.PlayerSummary"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "No shots of the player",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database not available",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    }
                 }
             }
         },
-        "internal_api.Response": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "error": {
+        "/scrape/batch": {
+            "post": {
+                "description": "Queue one scrape job per URL. A URL that cannot be queued is reported in its entry and does not stop the others. A gameweek is answered with a single entry for its listing page, whose batch job loads the listing in the background and queues a scrape job per match.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scraper"
+                ],
+                "summary": "Scrape a batch of matches",
+                "parameters": [
+                    {
+                        "description": "URLs, or season and gameweek",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.BatchScrapeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Jobs queued for each URL, or the batch job of a gameweek",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_api.BatchJob"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Batch job could not be recorded",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scrape/jobs/{id}": {
+            "get": {
+                "description": "Get the status of a scrape job: queued, running, succeeded (with the fixture) or failed (with the error and parse report). A failed job is answered with the HTTP status of its error_type and the job in data.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scraper"
+                ],
+                "summary": "Get scrape job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Queued, running or succeeded job",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/example_hello_internal_jobs.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Job failed: unsupported URL",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/example_hello_internal_jobs.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Job failed: disallowed by robots.txt",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/example_hello_internal_jobs.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found (no data), or job failed: page not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/example_hello_internal_jobs.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Job failed: page could not be parsed, or a strict scrape was rejected as incomplete (see data.report)",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/example_hello_internal_jobs.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "502": {
+                        "description": "Job failed for another reason",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/example_hello_internal_jobs.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Job failed: blocked by the site",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/example_hello_internal_jobs.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "504": {
+                        "description": "Job failed: page did not load in time",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/example_hello_internal_jobs.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/scrape/xgstats": {
+            "post": {
+                "description": "Queue a scrape of xG statistics and shot map data from a match page of a supported site (xgstat.com, understat.com). Poll the returned job for the result.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scraper"
+                ],
+                "summary": "Scrape xG shot map data",
+                "parameters": [
+                    {
+                        "description": "Scrape request with a match page URL",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.ScrapeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Scrape job accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/example_hello_internal_jobs.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or a URL no provider handles",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Job queue is full",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/table": {
+            "get": {
+                "description": "Points, goal difference and xG of every team, and expected points (xPts) from each match's shots (or the teams' xG when the shots carry none), over a gameweek range, for all matches or only home or away ones. xgstat shots carry no xG, so with the default source every xPts is a Poisson estimate from the teams' xG and xpts_estimated equals played; understat and statsbomb give shot-based xPts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fixtures"
+                ],
+                "summary": "Get the league table with expected points",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source of the fixtures: xgstat (default, xPts estimated from team xG), understat or statsbomb",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "First gameweek",
+                        "name": "from_gameweek",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last gameweek",
+                        "name": "to_gameweek",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "all (default), home or away",
+                        "name": "venue",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "League table",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/example_hello_internal_table.Row"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database not available",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{team}/summary": {
+            "get": {
+                "description": "Matches, goals, xG and shots for and against, and per-90 rates, over the fixtures in a date or gameweek range",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fixtures"
+                ],
+                "summary": "Get a team's season summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team name, ignoring case",
+                        "name": "team",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Source of the fixtures: xgstat (default), understat or statsbomb",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First match date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last match date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "First gameweek",
+                        "name": "from_gameweek",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last gameweek",
+                        "name": "to_gameweek",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Team summary",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/example_hello_internal_domain.TeamSummary"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "No fixtures of the team",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database not available",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/xgstats": {
+            "get": {
+                "description": "Retrieve saved xG statistics and shot map data from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scraper"
+                ],
+                "summary": "Get xG statistics by fixture ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fixture ID; id or match_key is required",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Match key, e.g. the xgstat URL slug; unlike the ID it is unique within a source",
+                        "name": "match_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Source the fixture came from, e.g. xgstat (default), understat or statsbomb",
+                        "name": "source",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Retrieved xG statistics",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/internal_api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/example_hello_internal_domain.DBXGStatFixture"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Fixture not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "More than one fixture has this ID; the error lists their match keys",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database not available",
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "example_hello_internal_domain.DBDiscoveredFixture": {
+            "type": "object",
+            "properties": {
+                "competition": {
+                    "type": "string"
+                },
+                "discovered_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "gameweek": {
+                    "type": "integer"
+                },
+                "kickoff": {
+                    "type": "string"
+                },
+                "scraped_at": {
+                    "type": "string"
+                },
+                "season": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_hello_internal_domain.DBFixtureSummary": {
+            "type": "object",
+            "properties": {
+                "away_score": {
+                    "type": "integer"
+                },
+                "away_team": {
+                    "type": "string"
+                },
+                "away_xg": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "gameweek": {
+                    "type": "integer"
+                },
+                "home_score": {
+                    "type": "integer"
+                },
+                "home_team": {
+                    "type": "string"
+                },
+                "home_xg": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "match_key": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_hello_internal_domain.DBXGStatFixture": {
+            "type": "object",
+            "properties": {
+                "away_score": {
+                    "type": "integer"
+                },
+                "away_shots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_hello_internal_domain.DBXGStatShot"
+                    }
+                },
+                "away_team": {
+                    "type": "string"
+                },
+                "away_xg": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "gameweek": {
+                    "type": "integer"
+                },
+                "home_score": {
+                    "type": "integer"
+                },
+                "home_shots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_hello_internal_domain.DBXGStatShot"
+                    }
+                },
+                "home_team": {
+                    "type": "string"
+                },
+                "home_xg": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "match_key": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_hello_internal_domain.DBXGStatShot": {
+            "type": "object",
+            "properties": {
+                "angle": {
+                    "type": "number"
+                },
+                "body_part": {
+                    "type": "string"
+                },
+                "coordinate_system": {
+                    "type": "string"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "is_goal": {
+                    "type": "boolean"
+                },
+                "minute": {
+                    "type": "integer"
+                },
+                "play_pattern": {
+                    "type": "string"
+                },
+                "player_name": {
+                    "type": "string"
+                },
+                "shot_type": {
+                    "type": "string"
+                },
+                "situation": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "number"
+                },
+                "xg": {
+                    "type": "number"
+                },
+                "y": {
+                    "type": "number"
+                },
+                "zone": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_hello_internal_domain.FixturePage": {
+            "type": "object",
+            "properties": {
+                "fixtures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_hello_internal_domain.DBFixtureSummary"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_hello_internal_domain.PlayerShot": {
+            "type": "object",
+            "properties": {
+                "angle": {
+                    "type": "number"
+                },
+                "body_part": {
+                    "type": "string"
+                },
+                "coordinate_system": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "gameweek": {
+                    "type": "integer"
+                },
+                "is_goal": {
+                    "type": "boolean"
+                },
+                "minute": {
+                    "type": "integer"
+                },
+                "opponent": {
+                    "type": "string"
+                },
+                "play_pattern": {
+                    "type": "string"
+                },
+                "player_name": {
+                    "type": "string"
+                },
+                "shot_type": {
+                    "type": "string"
+                },
+                "situation": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "team": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "number"
+                },
+                "xg": {
+                    "type": "number"
+                },
+                "y": {
+                    "type": "number"
+                },
+                "zone": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_hello_internal_domain.PlayerSummary": {
+            "type": "object",
+            "properties": {
+                "average_distance": {
+                    "type": "number"
+                },
+                "goals": {
+                    "type": "integer"
+                },
+                "goals_minus_xg": {
+                    "type": "number"
+                },
+                "matches": {
+                    "type": "integer"
+                },
+                "player": {
+                    "type": "string"
+                },
+                "shot_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example_hello_internal_domain.ShotTypeTotals"
+                    }
+                },
+                "shots": {
+                    "type": "integer"
+                },
+                "shots_per_match": {
+                    "type": "number"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "team": {
+                    "type": "string"
+                },
+                "xg": {
+                    "type": "number"
+                }
+            }
+        },
+        "example_hello_internal_domain.ShotTypeTotals": {
+            "type": "object",
+            "properties": {
+                "goals": {
+                    "type": "integer"
+                },
+                "shot_type": {
+                    "type": "string"
+                },
+                "shots": {
+                    "type": "integer"
+                },
+                "xg": {
+                    "type": "number"
+                }
+            }
+        },
+        "example_hello_internal_domain.TeamRates": {
+            "type": "object",
+            "properties": {
+                "goals_against": {
+                    "type": "number"
+                },
+                "goals_for": {
+                    "type": "number"
+                },
+                "shots": {
+                    "type": "number"
+                },
+                "shots_on_target": {
+                    "type": "number"
+                },
+                "xg_against": {
+                    "type": "number"
+                },
+                "xg_for": {
+                    "type": "number"
+                }
+            }
+        },
+        "example_hello_internal_domain.TeamSummary": {
+            "type": "object",
+            "properties": {
+                "goals_against": {
+                    "type": "integer"
+                },
+                "goals_for": {
+                    "type": "integer"
+                },
+                "matches": {
+                    "type": "integer"
+                },
+                "per_90": {
+                    "$ref": "#/definitions/example_hello_internal_domain.TeamRates"
+                },
+                "shots": {
+                    "type": "integer"
+                },
+                "shots_against": {
+                    "type": "integer"
+                },
+                "shots_on_target": {
+                    "type": "integer"
+                },
+                "shots_on_target_against": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "team": {
+                    "type": "string"
+                },
+                "xg_against": {
+                    "type": "number"
+                },
+                "xg_difference": {
+                    "type": "number"
+                },
+                "xg_for": {
+                    "type": "number"
+                }
+            }
+        },
+        "example_hello_internal_jobs.Job": {
+            "type": "object",
+            "properties": {
+                "batch": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "error_type": {
+                    "description": "ErrorType classifies a failure as scraper.ErrorKind does; a strict\nscrape rejected for an incomplete report is \"incomplete\"",
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "fixture": {
+                    "description": "Fixture is set once the job has succeeded",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/example_hello_internal_domain.DBXGStatFixture"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jobs": {
+                    "description": "Jobs are the IDs of the scrape jobs a batch job has queued",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "report": {
+                    "description": "Report is set whenever the page was fetched, including failed parses",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/example_hello_internal_scraper.ParseReport"
+                        }
+                    ]
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/example_hello_internal_jobs.Status"
+                },
+                "strict": {
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_hello_internal_jobs.Status": {
+            "type": "string",
+            "enum": [
+                "queued",
+                "running",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "StatusQueued",
+                "StatusRunning",
+                "StatusSucceeded",
+                "StatusFailed"
+            ]
+        },
+        "example_hello_internal_scraper.ParseReport": {
+            "type": "object",
+            "properties": {
+                "away": {
+                    "$ref": "#/definitions/example_hello_internal_scraper.TeamCheck"
+                },
+                "complete": {
+                    "type": "boolean"
+                },
+                "fields_found": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "fields_missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "gaps": {
+                    "description": "Gaps lists data the page itself does not provide, e.g. per-shot xG on\nxgstat.com. Unlike Issues they do not make the report incomplete.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "home": {
+                    "$ref": "#/definitions/example_hello_internal_scraper.TeamCheck"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "shot_detail": {
+                    "description": "ShotDetail is set when every shot names its player and has a minute\nand an xG. xgstat.com shot markers carry none of the three, so its\nshots are stored with an empty player, minute 0 and xG 0.",
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "example_hello_internal_scraper.TeamCheck": {
+            "type": "object",
+            "properties": {
+                "goals": {
+                    "type": "integer"
+                },
+                "headline_xg": {
+                    "type": "number"
+                },
+                "shot_xg": {
+                    "type": "number"
+                },
+                "shots": {
+                    "type": "integer"
+                },
+                "shots_with_xg": {
+                    "type": "integer"
+                },
+                "table_goals": {
+                    "type": "integer"
+                },
+                "table_shots": {
+                    "description": "-1 when the player table has no shots column",
+                    "type": "integer"
+                },
+                "table_xg": {
+                    "type": "number"
+                },
+                "unattributed_shots": {
+                    "description": "shots without a player",
+                    "type": "integer"
+                }
+            }
+        },
+        "example_hello_internal_table.Row": {
+            "type": "object",
+            "properties": {
+                "drawn": {
+                    "type": "integer"
+                },
+                "goal_difference": {
+                    "type": "integer"
+                },
+                "goals_against": {
+                    "type": "integer"
+                },
+                "goals_for": {
+                    "type": "integer"
+                },
+                "lost": {
+                    "type": "integer"
+                },
+                "played": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "points_minus_xpts": {
+                    "type": "number"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "team": {
+                    "type": "string"
+                },
+                "won": {
+                    "type": "integer"
+                },
+                "xg_against": {
+                    "type": "number"
+                },
+                "xg_for": {
+                    "type": "number"
+                },
+                "xpts": {
+                    "type": "number"
+                },
+                "xpts_estimated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_api.BatchJob": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_api.BatchScrapeRequest": {
+            "type": "object",
+            "properties": {
+                "competition": {
+                    "description": "Competition defaults to premier-league when a gameweek is given",
+                    "type": "string"
+                },
+                "gameweek": {
+                    "type": "integer"
+                },
+                "season": {
+                    "description": "Season such as 2025-2026, required with gameweek",
+                    "type": "string"
+                },
+                "strict": {
+                    "type": "boolean"
+                },
+                "urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_api.DiscoverRequest": {
+            "type": "object",
+            "properties": {
+                "competition": {
+                    "description": "Competition defaults to premier-league",
+                    "type": "string"
+                },
+                "from_gameweek": {
+                    "description": "FromGameweek and ToGameweek default to the whole season",
+                    "type": "integer"
+                },
+                "scrape": {
+                    "description": "Scrape queues a job for every pending fixture once the crawl is done",
+                    "type": "boolean"
+                },
+                "season": {
+                    "description": "Season such as 2025-2026",
+                    "type": "string"
+                },
+                "strict": {
+                    "type": "boolean"
+                },
+                "to_gameweek": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_api.Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
                     "type": "string"
                 },
                 "success": {
@@ -224,6 +1647,10 @@ const docTemplate = `{
         "internal_api.ScrapeRequest": {
             "type": "object",
             "properties": {
+                "strict": {
+                    "description": "Strict rejects fixtures whose parse report is incomplete instead of saving them",
+                    "type": "boolean"
+                },
                 "url": {
                     "type": "string"
                 }
@@ -1,25 +1,40 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"example/hello/internal/database"
+	"example/hello/internal/domain"
+	"example/hello/internal/jobs"
 	"example/hello/internal/scraper"
+	"example/hello/internal/table"
 )
 
 // Handler handles HTTP requests for the scraper API
 type Handler struct {
 	scraperService  *scraper.Service
 	databaseService *database.Service
+	fixtures        database.FixtureRepository
+	jobQueue        *jobs.Queue
 }
 
-// NewHandler creates a new API handler
-func NewHandler(scraperService *scraper.Service, databaseService *database.Service) *Handler {
+// NewHandler creates a new API handler. databaseService is only set when
+// fixtures are stored in Postgres; either may be nil when no database is
+// available.
+func NewHandler(scraperService *scraper.Service, databaseService *database.Service, fixtures database.FixtureRepository, jobQueue *jobs.Queue) *Handler {
 	return &Handler{
 		scraperService:  scraperService,
 		databaseService: databaseService,
+		fixtures:        fixtures,
+		jobQueue:        jobQueue,
 	}
 }
 
@@ -33,18 +48,54 @@ type Response struct {
 // ScrapeRequest represents a request to scrape a website
 type ScrapeRequest struct {
 	URL string `json:"url"`
+	// Strict rejects fixtures whose parse report is incomplete instead of saving them
+	Strict bool `json:"strict"`
 }
 
-// ScrapeXGStats scrapes xG shot map data from xgstat.com
+// BatchScrapeRequest asks for several match pages to be scraped, given either
+// as URLs or as a gameweek to look up on xgstat.com
+type BatchScrapeRequest struct {
+	URLs []string `json:"urls"`
+	// Competition defaults to premier-league when a gameweek is given
+	Competition string `json:"competition"`
+	// Season such as 2025-2026, required with gameweek
+	Season   string `json:"season"`
+	Gameweek int    `json:"gameweek"`
+	Strict   bool   `json:"strict"`
+}
+
+// BatchJob is the outcome of queueing one URL of a batch
+type BatchJob struct {
+	URL   string `json:"url"`
+	JobID string `json:"job_id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// DiscoverRequest asks for a competition's gameweek listing pages to be crawled
+type DiscoverRequest struct {
+	// Competition defaults to premier-league
+	Competition string `json:"competition"`
+	// Season such as 2025-2026
+	Season string `json:"season"`
+	// FromGameweek and ToGameweek default to the whole season
+	FromGameweek int `json:"from_gameweek"`
+	ToGameweek   int `json:"to_gameweek"`
+	// Scrape queues a job for every pending fixture once the crawl is done
+	Scrape bool `json:"scrape"`
+	Strict bool `json:"strict"`
+}
+
+// ScrapeXGStats queues a scrape of an xgstat.com match page
 // @Summary Scrape xG shot map data
-// @Description Scrape xG statistics and shot map data from xgstat.com
+// @Description Queue a scrape of xG statistics and shot map data from a match page of a supported site (xgstat.com, understat.com). Poll the returned job for the result.
 // @Tags scraper
 // @Accept json
 // @Produce json
-// @Param request body ScrapeRequest true "Scrape request with xgstat.com URL"
-// @Success 200 {object} Response{data=example_hello_internal_domain.DBXGStatFixture} "Scraped xG statistics and shot map data"
-// @Failure 400 {object} Response "Invalid request"
+// @Param request body ScrapeRequest true "Scrape request with a match page URL"
+// @Success 202 {object} Response{data=example_hello_internal_jobs.Job} "Scrape job accepted"
+// @Failure 400 {object} Response "Invalid request or a URL no provider handles"
 // @Failure 405 {object} Response "Method not allowed"
+// @Failure 503 {object} Response "Job queue is full"
 // @Router /scrape/xgstats [post]
 func (h *Handler) ScrapeXGStats(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -62,22 +113,281 @@ func (h *Handler) ScrapeXGStats(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "URL is required")
 		return
 	}
+	if _, err := h.scraperService.Provider(req.URL); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	data, err := h.scraperService.ScrapeXGStatFixture(req.URL)
+	job, err := h.jobQueue.Submit(r.Context(), req.URL, req.Strict)
+	if err != nil {
+		if errors.Is(err, jobs.ErrQueueFull) {
+			writeError(w, http.StatusServiceUnavailable, "Scrape queue is full, try again later")
+		} else {
+			writeError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	w.Header().Set("Location", "/api/scrape/jobs/"+job.ID)
+	writeSuccessWithStatus(w, http.StatusAccepted, job)
+}
+
+// BatchScrapeXGStats queues a scrape job for every match in a URL list or gameweek
+// @Summary Scrape a batch of matches
+// @Description Queue one scrape job per URL. A URL that cannot be queued is reported in its entry and does not stop the others. A gameweek is answered with a single entry for its listing page, whose batch job loads the listing in the background and queues a scrape job per match.
+// @Tags scraper
+// @Accept json
+// @Produce json
+// @Param request body BatchScrapeRequest true "URLs, or season and gameweek"
+// @Success 202 {object} Response{data=[]BatchJob} "Jobs queued for each URL, or the batch job of a gameweek"
+// @Failure 400 {object} Response "Invalid request"
+// @Failure 405 {object} Response "Method not allowed"
+// @Failure 500 {object} Response "Batch job could not be recorded"
+// @Router /scrape/batch [post]
+func (h *Handler) BatchScrapeXGStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req BatchScrapeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	switch {
+	case len(req.URLs) > 0 && req.Gameweek > 0:
+		writeError(w, http.StatusBadRequest, "Give either urls or a gameweek, not both")
+		return
+	case req.Gameweek > 0:
+		h.batchScrapeGameweek(w, r, req)
+		return
+	case len(req.URLs) == 0:
+		writeError(w, http.StatusBadRequest, "Either urls or season and gameweek are required")
+		return
+	}
+
+	batch := make([]BatchJob, 0, len(req.URLs))
+	seen := make(map[string]bool)
+	for _, url := range req.URLs {
+		if url == "" || seen[url] {
+			continue
+		}
+		seen[url] = true
+
+		entry := BatchJob{URL: url}
+		if _, err := h.scraperService.Provider(url); err != nil {
+			entry.Error = err.Error()
+		} else if job, err := h.jobQueue.Submit(r.Context(), url, req.Strict); err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.JobID = job.ID
+		}
+		batch = append(batch, entry)
+	}
+
+	writeSuccessWithStatus(w, http.StatusAccepted, batch)
+}
+
+// batchScrapeGameweek queues a batch job that loads a gameweek listing and
+// queues its matches. The listing can take minutes to load with retries and
+// politeness delays, so it is never fetched while the request waits.
+func (h *Handler) batchScrapeGameweek(w http.ResponseWriter, r *http.Request, req BatchScrapeRequest) {
+	if req.Season == "" {
+		writeError(w, http.StatusBadRequest, "Season is required with gameweek")
+		return
+	}
+	if err := scraper.CheckGameweek(req.Season, req.Gameweek); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Competition == "" {
+		req.Competition = scraper.DefaultCompetition
+	}
+
+	listingURL := scraper.GameweekListingURL(req.Competition, req.Season, req.Gameweek)
+	job, err := h.jobQueue.SubmitBatch(r.Context(), listingURL, req.Strict, func(ctx context.Context) ([]string, error) {
+		return h.scraperService.GameweekMatchURLs(ctx, req.Competition, req.Season, req.Gameweek)
+	})
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	// Save to database if service is available
-	if h.databaseService != nil {
-		if err := h.databaseService.SaveXGStatFixture(data); err != nil {
-			writeError(w, http.StatusInternalServerError, "Failed to save data: "+err.Error())
+	w.Header().Set("Location", "/api/scrape/jobs/"+job.ID)
+	writeSuccessWithStatus(w, http.StatusAccepted, []BatchJob{{URL: listingURL, JobID: job.ID}})
+}
+
+// DiscoverFixtures crawls gameweek listing pages in the background
+// @Summary Discover fixtures
+// @Description Crawl the gameweek listing pages of a season and store every match found as a pending discovered fixture. Optionally queue a scrape job for each pending fixture afterwards.
+// @Tags discovery
+// @Accept json
+// @Produce json
+// @Param request body DiscoverRequest true "Season and gameweek range to crawl"
+// @Success 202 {object} Response{data=DiscoverRequest} "Crawl started"
+// @Failure 400 {object} Response "Invalid request"
+// @Failure 405 {object} Response "Method not allowed"
+// @Failure 503 {object} Response "Database not available"
+// @Router /discover [post]
+func (h *Handler) DiscoverFixtures(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if h.databaseService == nil {
+		writeError(w, http.StatusServiceUnavailable, "Database not available")
+		return
+	}
+
+	var req DiscoverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Season == "" {
+		writeError(w, http.StatusBadRequest, "Season is required")
+		return
+	}
+	if req.Competition == "" {
+		req.Competition = scraper.DefaultCompetition
+	}
+	if req.FromGameweek == 0 {
+		req.FromGameweek = 1
+	}
+	if req.ToGameweek == 0 {
+		req.ToGameweek = scraper.SeasonGameweeks
+	}
+	if req.FromGameweek < 1 || req.ToGameweek < req.FromGameweek {
+		writeError(w, http.StatusBadRequest, "Invalid gameweek range")
+		return
+	}
+
+	// The crawl outlives the request, so it runs on the job queue's lifetime
+	h.jobQueue.Go(func(ctx context.Context) {
+		jobs.Discover(ctx, h.scraperService, h.databaseService, req.Competition, req.Season, req.FromGameweek, req.ToGameweek)
+		if !req.Scrape {
 			return
 		}
+
+		urls, err := jobs.PendingURLs(ctx, h.databaseService, domain.DiscoveryFilter{
+			Competition: req.Competition,
+			Season:      req.Season,
+		})
+		if err != nil {
+			log.Printf("❌ Failed to list pending fixtures: %v", err)
+			return
+		}
+		for _, url := range urls {
+			if _, err := h.jobQueue.SubmitBlocking(ctx, url, req.Strict); err != nil {
+				log.Printf("❌ Failed to queue %s: %v", url, err)
+				return
+			}
+		}
+		log.Printf("📥 Queued %d discovered fixtures for scraping", len(urls))
+	})
+
+	writeSuccessWithStatus(w, http.StatusAccepted, req)
+}
+
+// ListDiscoveredFixtures lists discovered fixtures and their scrape status
+// @Summary List discovered fixtures
+// @Description List fixtures found by discovery, optionally filtered by competition, season, gameweek and status
+// @Tags discovery
+// @Produce json
+// @Param competition query string false "Competition, e.g. premier-league"
+// @Param season query string false "Season, e.g. 2025-2026"
+// @Param gameweek query int false "Gameweek"
+// @Param status query string false "pending, scraped or failed"
+// @Param limit query int false "Maximum number of fixtures"
+// @Success 200 {object} Response{data=[]example_hello_internal_domain.DBDiscoveredFixture} "Discovered fixtures"
+// @Failure 400 {object} Response "Invalid request"
+// @Failure 503 {object} Response "Database not available"
+// @Router /discovered [get]
+func (h *Handler) ListDiscoveredFixtures(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
 	}
 
-	writeSuccess(w, data)
+	if h.databaseService == nil {
+		writeError(w, http.StatusServiceUnavailable, "Database not available")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := domain.DiscoveryFilter{
+		Competition: query.Get("competition"),
+		Season:      query.Get("season"),
+		Status:      query.Get("status"),
+	}
+
+	switch filter.Status {
+	case "", domain.DiscoveryPending, domain.DiscoveryScraped, domain.DiscoveryFailed:
+	default:
+		writeError(w, http.StatusBadRequest, "Invalid status")
+		return
+	}
+
+	var err error
+	if filter.Gameweek, err = queryInt(r, "gameweek"); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid gameweek")
+		return
+	}
+	if filter.Limit, err = queryInt(r, "limit"); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+
+	fixtures, err := h.databaseService.ListDiscoveredFixtures(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, fixtures)
+}
+
+// GetScrapeJob reports the status of a scrape job
+// @Summary Get scrape job status
+// @Description Get the status of a scrape job: queued, running, succeeded (with the fixture) or failed (with the error and parse report). A failed job is answered with the HTTP status of its error_type and the job in data.
+// @Tags scraper
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} Response{data=example_hello_internal_jobs.Job} "Queued, running or succeeded job"
+// @Failure 400 {object} Response{data=example_hello_internal_jobs.Job} "Job failed: unsupported URL"
+// @Failure 403 {object} Response{data=example_hello_internal_jobs.Job} "Job failed: disallowed by robots.txt"
+// @Failure 404 {object} Response{data=example_hello_internal_jobs.Job} "Job not found (no data), or job failed: page not found"
+// @Failure 405 {object} Response "Method not allowed"
+// @Failure 422 {object} Response{data=example_hello_internal_jobs.Job} "Job failed: page could not be parsed, or a strict scrape was rejected as incomplete (see data.report)"
+// @Failure 502 {object} Response{data=example_hello_internal_jobs.Job} "Job failed for another reason"
+// @Failure 503 {object} Response{data=example_hello_internal_jobs.Job} "Job failed: blocked by the site"
+// @Failure 504 {object} Response{data=example_hello_internal_jobs.Job} "Job failed: page did not load in time"
+// @Router /scrape/jobs/{id} [get]
+func (h *Handler) GetScrapeJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	job, err := h.jobQueue.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, jobs.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "Job not found")
+		} else {
+			writeError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	if job.Status == jobs.StatusFailed {
+		writeErrorWithData(w, jobErrorStatus(job.ErrorType), job.Error, job)
+		return
+	}
+	writeSuccess(w, job)
 }
 
 // GetXGStatFixture retrieves a saved fixture by ID
@@ -86,10 +396,14 @@ func (h *Handler) ScrapeXGStats(w http.ResponseWriter, r *http.Request) {
 // @Tags scraper
 // @Accept json
 // @Produce json
-// @Param id query int true "Fixture ID"
+// @Param id query int false "Fixture ID; id or match_key is required"
+// @Param match_key query string false "Match key, e.g. the xgstat URL slug; unlike the ID it is unique within a source"
+// @Param source query string false "Source the fixture came from, e.g. xgstat (default), understat or statsbomb"
 // @Success 200 {object} Response{data=example_hello_internal_domain.DBXGStatFixture} "Retrieved xG statistics"
 // @Failure 400 {object} Response "Invalid request"
 // @Failure 404 {object} Response "Fixture not found"
+// @Failure 409 {object} Response "More than one fixture has this ID; the error lists their match keys"
+// @Failure 503 {object} Response "Database not available"
 // @Router /xgstats [get]
 func (h *Handler) GetXGStatFixture(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -97,23 +411,41 @@ func (h *Handler) GetXGStatFixture(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fixtureIDStr := r.URL.Query().Get("id")
-	if fixtureIDStr == "" {
-		writeError(w, http.StatusBadRequest, "Fixture ID is required")
+	if h.fixtures == nil {
+		writeError(w, http.StatusServiceUnavailable, "Database not available")
 		return
 	}
 
-	var fixtureID int
-	if _, err := fmt.Sscanf(fixtureIDStr, "%d", &fixtureID); err != nil {
-		writeError(w, http.StatusBadRequest, "Invalid fixture ID")
+	source, err := querySource(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errUnknownSource)
 		return
 	}
 
-	data, err := h.databaseService.GetFixtureByID(fixtureID)
+	var data *domain.DBXGStatFixture
+	if matchKey := r.URL.Query().Get("match_key"); matchKey != "" {
+		data, err = h.fixtures.GetFixtureByKey(r.Context(), source, matchKey)
+	} else {
+		fixtureIDStr := r.URL.Query().Get("id")
+		if fixtureIDStr == "" {
+			writeError(w, http.StatusBadRequest, "Fixture ID or match key is required")
+			return
+		}
+
+		var fixtureID int
+		if _, err := fmt.Sscanf(fixtureIDStr, "%d", &fixtureID); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid fixture ID")
+			return
+		}
+		data, err = h.fixtures.GetFixtureByID(r.Context(), source, fixtureID)
+	}
 	if err != nil {
-		if err.Error() == "fixture not found" {
+		switch {
+		case errors.Is(err, database.ErrFixtureNotFound):
 			writeError(w, http.StatusNotFound, "Fixture not found")
-		} else {
+		case errors.Is(err, database.ErrFixtureAmbiguous):
+			writeError(w, http.StatusConflict, err.Error()+"; request one by match_key")
+		default:
 			writeError(w, http.StatusInternalServerError, err.Error())
 		}
 		return
@@ -122,18 +454,436 @@ func (h *Handler) GetXGStatFixture(w http.ResponseWriter, r *http.Request) {
 	writeSuccess(w, data)
 }
 
-// Health returns the health status
+// ListFixtures lists saved fixtures, one page at a time
+// @Summary List saved fixtures
+// @Description List saved fixtures without their shots, filtered and sorted. Pass next_cursor as cursor to get the next page.
+// @Tags fixtures
+// @Produce json
+// @Param source query string false "Source of the fixtures: xgstat (default), understat or statsbomb"
+// @Param gameweek query int false "Gameweek"
+// @Param team query string false "Home or away team, ignoring case"
+// @Param from query string false "First match date (YYYY-MM-DD)"
+// @Param to query string false "Last match date (YYYY-MM-DD)"
+// @Param min_xg query number false "Minimum total xG of both teams"
+// @Param max_xg query number false "Maximum total xG of both teams"
+// @Param result query string false "home, away or draw; win or loss for the team"
+// @Param sort query string false "date, gameweek or xg, prefixed with - for descending order (default -date)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Fixtures per page (default 50, at most 200)"
+// @Success 200 {object} Response{data=example_hello_internal_domain.FixturePage} "Fixtures"
+// @Failure 400 {object} Response "Invalid request"
+// @Failure 503 {object} Response "Database not available"
+// @Router /fixtures [get]
+func (h *Handler) ListFixtures(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if h.fixtures == nil {
+		writeError(w, http.StatusServiceUnavailable, "Database not available")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := domain.FixtureFilter{
+		Team:   query.Get("team"),
+		Result: query.Get("result"),
+		Sort:   query.Get("sort"),
+		Cursor: query.Get("cursor"),
+	}
+
+	var err error
+	if filter.Source, err = querySource(r); err != nil {
+		writeError(w, http.StatusBadRequest, errUnknownSource)
+		return
+	}
+
+	switch filter.Result {
+	case "", domain.ResultHome, domain.ResultAway, domain.ResultDraw:
+	case domain.ResultWin, domain.ResultLoss:
+		if filter.Team == "" {
+			writeError(w, http.StatusBadRequest, "Result win or loss needs a team")
+			return
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "Invalid result")
+		return
+	}
+
+	switch strings.TrimPrefix(filter.Sort, "-") {
+	case "", domain.FixtureSortDate, domain.FixtureSortGameweek, domain.FixtureSortXG:
+	default:
+		writeError(w, http.StatusBadRequest, "Invalid sort")
+		return
+	}
+
+	if filter.Gameweek, err = queryInt(r, "gameweek"); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid gameweek")
+		return
+	}
+	if filter.Limit, err = queryInt(r, "limit"); err != nil || filter.Limit < 0 {
+		writeError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+	if filter.MinXG, err = queryFloat(r, "min_xg"); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid min_xg")
+		return
+	}
+	if filter.MaxXG, err = queryFloat(r, "max_xg"); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid max_xg")
+		return
+	}
+	if filter.From, filter.Until, err = queryDateRange(r); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid date, use YYYY-MM-DD")
+		return
+	}
+
+	page, err := h.fixtures.ListFixtures(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, database.ErrInvalidCursor) {
+			writeError(w, http.StatusBadRequest, "Invalid cursor")
+		} else {
+			writeError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	writeSuccess(w, page)
+}
+
+// GetTeamSummary aggregates a team's saved fixtures
+// @Summary Get a team's season summary
+// @Description Matches, goals, xG and shots for and against, and per-90 rates, over the fixtures in a date or gameweek range
+// @Tags fixtures
+// @Produce json
+// @Param team path string true "Team name, ignoring case"
+// @Param source query string false "Source of the fixtures: xgstat (default), understat or statsbomb"
+// @Param from query string false "First match date (YYYY-MM-DD)"
+// @Param to query string false "Last match date (YYYY-MM-DD)"
+// @Param from_gameweek query int false "First gameweek"
+// @Param to_gameweek query int false "Last gameweek"
+// @Success 200 {object} Response{data=example_hello_internal_domain.TeamSummary} "Team summary"
+// @Failure 400 {object} Response "Invalid request"
+// @Failure 404 {object} Response "No fixtures of the team"
+// @Failure 503 {object} Response "Database not available"
+// @Router /teams/{team}/summary [get]
+func (h *Handler) GetTeamSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if h.fixtures == nil {
+		writeError(w, http.StatusServiceUnavailable, "Database not available")
+		return
+	}
+
+	team := r.PathValue("team")
+	if team == "" {
+		writeError(w, http.StatusBadRequest, "Team is required")
+		return
+	}
+
+	var filter domain.TeamFilter
+	var err error
+	if filter.Source, err = querySource(r); err != nil {
+		writeError(w, http.StatusBadRequest, errUnknownSource)
+		return
+	}
+	if filter.From, filter.Until, err = queryDateRange(r); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid date, use YYYY-MM-DD")
+		return
+	}
+	if filter.FromGameweek, err = queryInt(r, "from_gameweek"); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid from_gameweek")
+		return
+	}
+	if filter.ToGameweek, err = queryInt(r, "to_gameweek"); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid to_gameweek")
+		return
+	}
+	if filter.ToGameweek > 0 && filter.FromGameweek > filter.ToGameweek {
+		writeError(w, http.StatusBadRequest, "from_gameweek must not be after to_gameweek")
+		return
+	}
+
+	summary, err := h.fixtures.TeamSummary(r.Context(), team, filter)
+	if err != nil {
+		if errors.Is(err, database.ErrTeamNotFound) {
+			writeError(w, http.StatusNotFound, "No fixtures found for team")
+		} else {
+			writeError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	writeSuccess(w, summary)
+}
+
+// GetPlayerShots lists a player's saved shots
+// @Summary List a player's shots
+// @Description Every saved shot of a player, with the fixture it was taken in, in match order
+// @Tags players
+// @Produce json
+// @Param name path string true "Player name, as stored with the shots"
+// @Param source query string false "Source of the fixtures: understat (default) or statsbomb; xgstat shots have no player, so xgstat is a 400"
+// @Param team query string false "Team the player shot for, ignoring case"
+// @Param from query string false "First match date (YYYY-MM-DD)"
+// @Param to query string false "Last match date (YYYY-MM-DD)"
+// @Success 200 {object} Response{data=[]example_hello_internal_domain.PlayerShot} "Shots"
+// @Failure 400 {object} Response "Invalid request"
+// @Failure 503 {object} Response "Database not available"
+// @Router /players/{name}/shots [get]
+func (h *Handler) GetPlayerShots(w http.ResponseWriter, r *http.Request) {
+	player, filter, ok := h.playerRequest(w, r)
+	if !ok {
+		return
+	}
+
+	shots, err := h.fixtures.PlayerShots(r.Context(), player, filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, shots)
+}
+
+// GetPlayerSummary aggregates a player's saved shots
+// @Summary Get a player's shooting profile
+// @Description Total xG, goals, goals minus xG, shots per match, average shot distance and a breakdown by shot type
+// @Tags players
+// @Produce json
+// @Param name path string true "Player name, as stored with the shots"
+// @Param source query string false "Source of the fixtures: understat (default) or statsbomb; xgstat shots have no player, so xgstat is a 400"
+// @Param team query string false "Team the player shot for, ignoring case"
+// @Param from query string false "First match date (YYYY-MM-DD)"
+// @Param to query string false "Last match date (YYYY-MM-DD)"
+// @Success 200 {object} Response{data=example_hello_internal_domain.PlayerSummary} "Shooting profile"
+// @Failure 400 {object} Response "Invalid request"
+// @Failure 404 {object} Response "No shots of the player"
+// @Failure 503 {object} Response "Database not available"
+// @Router /players/{name}/summary [get]
+func (h *Handler) GetPlayerSummary(w http.ResponseWriter, r *http.Request) {
+	player, filter, ok := h.playerRequest(w, r)
+	if !ok {
+		return
+	}
+
+	summary, err := h.fixtures.PlayerSummary(r.Context(), player, filter)
+	if err != nil {
+		if errors.Is(err, database.ErrPlayerNotFound) {
+			writeError(w, http.StatusNotFound, "No shots found for player")
+		} else {
+			writeError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	writeSuccess(w, summary)
+}
+
+// playerRequest checks a player request and reads its name and filter. It
+// writes the error response and returns false when the request cannot be served.
+func (h *Handler) playerRequest(w http.ResponseWriter, r *http.Request) (string, domain.PlayerFilter, bool) {
+	var filter domain.PlayerFilter
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return "", filter, false
+	}
+
+	if h.fixtures == nil {
+		writeError(w, http.StatusServiceUnavailable, "Database not available")
+		return "", filter, false
+	}
+
+	player := r.PathValue("name")
+	if player == "" {
+		writeError(w, http.StatusBadRequest, "Player name is required")
+		return "", filter, false
+	}
+
+	filter.Team = r.URL.Query().Get("team")
+
+	// Only sources whose shots name their player have players to look up
+	var err error
+	filter.Source = domain.SourceUnderstat
+	if r.URL.Query().Get("source") != "" {
+		if filter.Source, err = querySource(r); err != nil {
+			writeError(w, http.StatusBadRequest, errUnknownSource)
+			return "", filter, false
+		}
+	}
+	if !domain.SourceShotDetail(filter.Source) {
+		writeError(w, http.StatusBadRequest, "Shots from "+filter.Source+" have no player, use source understat or statsbomb")
+		return "", filter, false
+	}
+	if filter.From, filter.Until, err = queryDateRange(r); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid date, use YYYY-MM-DD")
+		return "", filter, false
+	}
+	return player, filter, true
+}
+
+// GetLeagueTable builds the league table of the saved fixtures
+// @Summary Get the league table with expected points
+// @Description Points, goal difference and xG of every team, and expected points (xPts) from each match's shots (or the teams' xG when the shots carry none), over a gameweek range, for all matches or only home or away ones. xgstat shots carry no xG, so with the default source every xPts is a Poisson estimate from the teams' xG and xpts_estimated equals played; understat and statsbomb give shot-based xPts.
+// @Tags fixtures
+// @Produce json
+// @Param source query string false "Source of the fixtures: xgstat (default, xPts estimated from team xG), understat or statsbomb"
+// @Param from_gameweek query int false "First gameweek"
+// @Param to_gameweek query int false "Last gameweek"
+// @Param venue query string false "all (default), home or away"
+// @Success 200 {object} Response{data=[]example_hello_internal_table.Row} "League table"
+// @Failure 400 {object} Response "Invalid request"
+// @Failure 503 {object} Response "Database not available"
+// @Router /table [get]
+func (h *Handler) GetLeagueTable(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if h.fixtures == nil {
+		writeError(w, http.StatusServiceUnavailable, "Database not available")
+		return
+	}
+
+	venue := r.URL.Query().Get("venue")
+	switch venue {
+	case "":
+		venue = table.VenueAll
+	case table.VenueAll, table.VenueHome, table.VenueAway:
+	default:
+		writeError(w, http.StatusBadRequest, "Invalid venue")
+		return
+	}
+
+	var filter domain.SeasonFilter
+	var err error
+	if filter.Source, err = querySource(r); err != nil {
+		writeError(w, http.StatusBadRequest, errUnknownSource)
+		return
+	}
+	if filter.FromGameweek, err = queryInt(r, "from_gameweek"); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid from_gameweek")
+		return
+	}
+	if filter.ToGameweek, err = queryInt(r, "to_gameweek"); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid to_gameweek")
+		return
+	}
+	if filter.ToGameweek > 0 && filter.FromGameweek > filter.ToGameweek {
+		writeError(w, http.StatusBadRequest, "from_gameweek must not be after to_gameweek")
+		return
+	}
+
+	fixtures, err := h.fixtures.SeasonFixtures(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, table.Build(fixtures, venue))
+}
+
+// Health returns the health status and the scraper's per-host rate limits
 func (h *Handler) Health(w http.ResponseWriter, r *http.Request) {
-	writeSuccess(w, map[string]string{
+	health := map[string]interface{}{
 		"status":  "healthy",
 		"service": "football-scraper-api",
-	})
+	}
+	if hosts := h.scraperService.HostStates(); hosts != nil {
+		health["rate_limits"] = hosts
+	}
+	writeSuccess(w, health)
+}
+
+// jobErrorStatus maps the error_type of a failed job to the HTTP status it
+// is reported with. Failures without a class are the site's fault as far as
+// the caller can tell.
+func jobErrorStatus(errorType string) int {
+	switch errorType {
+	case "not_found":
+		return http.StatusNotFound
+	case "disallowed":
+		return http.StatusForbidden
+	case "blocked":
+		return http.StatusServiceUnavailable
+	case "timeout":
+		return http.StatusGatewayTimeout
+	case "parse", "incomplete":
+		return http.StatusUnprocessableEntity
+	case "unsupported":
+		return http.StatusBadRequest
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// errUnknownSource is the response to a source query parameter naming no source
+const errUnknownSource = "Unknown source, use xgstat, understat or statsbomb"
+
+// querySource reads the source query parameter, xgstat when missing. Each
+// source stores its own copy of a match, so they are not mixed. An unknown
+// source is an error rather than an empty result.
+func querySource(r *http.Request) (string, error) {
+	source := r.URL.Query().Get("source")
+	if source == "" {
+		return domain.SourceXGStat, nil
+	}
+	if !domain.KnownSource(source) {
+		return "", fmt.Errorf("unknown source %q", source)
+	}
+	return source, nil
+}
+
+// queryInt reads an optional integer query parameter; a missing one is 0
+func queryInt(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// queryFloat reads an optional number query parameter; a missing one is 0
+func queryFloat(r *http.Request, name string) (float64, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// queryDateRange reads the optional from and to query parameters
+// (YYYY-MM-DD, UTC) as a range whose end is exclusive, so to includes the
+// whole day; a missing bound is the zero time
+func queryDateRange(r *http.Request) (from, until time.Time, err error) {
+	if value := r.URL.Query().Get("from"); value != "" {
+		if from, err = time.Parse("2006-01-02", value); err != nil {
+			return from, until, fmt.Errorf("invalid from date %q", value)
+		}
+	}
+	if value := r.URL.Query().Get("to"); value != "" {
+		if until, err = time.Parse("2006-01-02", value); err != nil {
+			return from, until, fmt.Errorf("invalid to date %q", value)
+		}
+		until = until.AddDate(0, 0, 1)
+	}
+	return from, until, nil
 }
 
 // writeSuccess writes a successful JSON response
 func writeSuccess(w http.ResponseWriter, data interface{}) {
+	writeSuccessWithStatus(w, http.StatusOK, data)
+}
+
+// writeSuccessWithStatus writes a successful JSON response with a non-200 status
+func writeSuccessWithStatus(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(Response{
 		Success: true,
 		Data:    data,
@@ -142,10 +892,16 @@ func writeSuccess(w http.ResponseWriter, data interface{}) {
 
 // writeError writes an error JSON response
 func writeError(w http.ResponseWriter, status int, message string) {
+	writeErrorWithData(w, status, message, nil)
+}
+
+// writeErrorWithData writes an error JSON response that carries details in data
+func writeErrorWithData(w http.ResponseWriter, status int, message string, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(Response{
 		Success: false,
+		Data:    data,
 		Error:   message,
 	})
 }
@@ -0,0 +1,103 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"example/hello/internal/config"
+)
+
+// Entry describes one archived copy of a page
+type Entry struct {
+	// ID locates the copy in its store: a file path or a database row ID
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	FetchedAt time.Time `json:"fetched_at"`
+	// Hash is the hex SHA-256 of the uncompressed HTML
+	Hash string `json:"hash"`
+	// Size is the length of the uncompressed HTML in bytes
+	Size int `json:"size"`
+}
+
+// Filter selects archived pages. Zero fields match everything.
+type Filter struct {
+	URL string
+	// Since keeps pages fetched at or after this time
+	Since time.Time
+}
+
+// Store keeps the raw HTML of fetched pages. A fetch whose HTML matches the
+// latest copy of its URL is not stored again.
+type Store interface {
+	SavePage(ctx context.Context, url string, fetchedAt time.Time, html string) error
+	// LatestPages returns the most recent copy of each matching URL, ordered by URL
+	LatestPages(ctx context.Context, filter Filter) ([]Entry, error)
+	LoadPage(ctx context.Context, entry Entry) (string, error)
+}
+
+// Open returns the store selected by cfg, or nil when archiving is off.
+// database is used for the postgres backend and may be nil otherwise.
+func Open(cfg config.ArchiveConfig, database Store) (Store, error) {
+	switch cfg.Backend {
+	case "", "none":
+		return nil, nil
+	case "file":
+		if cfg.Dir == "" {
+			return nil, fmt.Errorf("the file archive needs a directory")
+		}
+		return NewFileStore(cfg.Dir), nil
+	case "postgres":
+		if database == nil {
+			return nil, fmt.Errorf("the postgres archive needs a database connection")
+		}
+		return database, nil
+	default:
+		return nil, fmt.Errorf("unknown archive backend %q", cfg.Backend)
+	}
+}
+
+// Hash returns the hex SHA-256 of a page
+func Hash(html string) string {
+	sum := sha256.Sum256([]byte(html))
+	return hex.EncodeToString(sum[:])
+}
+
+// Compress gzips a page, recording its URL and fetch time in the gzip header
+func Compress(url string, fetchedAt time.Time, html string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	zw.Comment = url
+	zw.ModTime = fetchedAt
+
+	if _, err := io.WriteString(zw, html); err != nil {
+		return nil, fmt.Errorf("failed to compress page: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress page: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress returns the page stored by Compress
+func Decompress(data []byte) (string, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to open archived page: %w", err)
+	}
+	defer zr.Close()
+
+	html, err := io.ReadAll(zr)
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress archived page: %w", err)
+	}
+	return string(html), nil
+}
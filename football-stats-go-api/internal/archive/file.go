@@ -0,0 +1,199 @@
+package archive
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"example/hello/internal/scraper"
+)
+
+// timeLayout names archived files so they sort by fetch time
+const timeLayout = "20060102T150405.000000000Z"
+
+// maxDirName keeps per-URL directory names within filesystem limits
+const maxDirName = 150
+
+// FileStore archives pages as gzip files, one directory per URL:
+//
+//	<dir>/<url as a file name>/<fetch time>-<sha256>.html.gz
+//
+// The URL is kept in the gzip header, so the directory name may be shortened.
+type FileStore struct {
+	dir string
+	// mu stops concurrent saves of one URL from both writing the same page
+	mu sync.Mutex
+}
+
+// NewFileStore creates a store under dir; directories are created on first save
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// SavePage writes a compressed copy of the page unless the URL's latest copy
+// has the same content
+func (s *FileStore) SavePage(ctx context.Context, url string, fetchedAt time.Time, html string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hash := Hash(html)
+	urlDir := filepath.Join(s.dir, dirNameForURL(url))
+
+	if latest := latestFile(urlDir); latest != "" && hashFromName(latest) == hash {
+		return nil
+	}
+
+	data, err := Compress(url, fetchedAt, html)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(urlDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	name := fetchedAt.UTC().Format(timeLayout) + "-" + hash + ".html.gz"
+	tmp, err := os.CreateTemp(urlDir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create archive file: %w", err)
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write archive file: %w", errors.Join(writeErr, closeErr))
+	}
+	// Renaming makes the file appear complete or not at all
+	if err := os.Rename(tmp.Name(), filepath.Join(urlDir, name)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to store archive file: %w", err)
+	}
+	return nil
+}
+
+// LatestPages returns the newest copy of each archived URL
+func (s *FileStore) LatestPages(ctx context.Context, filter Filter) ([]Entry, error) {
+	dirs, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return []Entry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	entries := []Entry{}
+	for _, dir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if !dir.IsDir() {
+			continue
+		}
+
+		urlDir := filepath.Join(s.dir, dir.Name())
+		latest := latestFile(urlDir)
+		if latest == "" {
+			continue
+		}
+		entry, err := readEntry(filepath.Join(urlDir, latest))
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.URL != "" && entry.URL != filter.URL {
+			continue
+		}
+		if !filter.Since.IsZero() && entry.FetchedAt.Before(filter.Since) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].URL < entries[j].URL })
+	return entries, nil
+}
+
+// LoadPage reads and decompresses an archived page
+func (s *FileStore) LoadPage(ctx context.Context, entry Entry) (string, error) {
+	data, err := os.ReadFile(entry.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read archived page: %w", err)
+	}
+	return Decompress(data)
+}
+
+// readEntry describes an archived file from its name and gzip header
+func readEntry(path string) (Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to open archived page: %w", err)
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to read archived page %s: %w", path, err)
+	}
+	defer zr.Close()
+
+	name := filepath.Base(path)
+	fetchedAt, err := time.Parse(timeLayout, strings.SplitN(name, "-", 2)[0])
+	if err != nil {
+		return Entry{}, fmt.Errorf("unexpected archive file name %s", name)
+	}
+
+	// The uncompressed size is the last four bytes of a gzip file
+	size := 0
+	if info, err := f.Stat(); err == nil && info.Size() >= 4 {
+		trailer := make([]byte, 4)
+		if _, err := f.ReadAt(trailer, info.Size()-4); err == nil {
+			size = int(binary.LittleEndian.Uint32(trailer))
+		}
+	}
+
+	return Entry{
+		ID:        path,
+		URL:       zr.Comment,
+		FetchedAt: fetchedAt,
+		Hash:      hashFromName(name),
+		Size:      size,
+	}, nil
+}
+
+// latestFile returns the name of the newest archived file in dir, or ""
+func latestFile(dir string) string {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	// ReadDir sorts by name, and names start with the fetch time
+	for i := len(files) - 1; i >= 0; i-- {
+		if name := files[i].Name(); strings.HasSuffix(name, ".html.gz") {
+			return name
+		}
+	}
+	return ""
+}
+
+// hashFromName extracts the content hash from an archived file name
+func hashFromName(name string) string {
+	_, rest, _ := strings.Cut(name, "-")
+	return strings.TrimSuffix(rest, ".html.gz")
+}
+
+// dirNameForURL names the directory of a URL. Long names are shortened and
+// made unique with part of the URL's hash.
+func dirNameForURL(url string) string {
+	name := strings.TrimSuffix(scraper.FileNameForURL(url), ".html")
+	if len(name) > maxDirName {
+		name = name[:maxDirName] + "_" + Hash(url)[:12]
+	}
+	return name
+}
@@ -9,9 +9,13 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	App      AppConfig
-	Server   ServerConfig
-	Database DatabaseConfig
+	App       AppConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Scraper   ScraperConfig
+	Jobs      JobsConfig
+	Scheduler SchedulerConfig
+	Archive   ArchiveConfig
 }
 
 // AppConfig holds application-level settings
@@ -35,6 +39,7 @@ type ServerConfig struct {
 type DatabaseConfig struct {
 	URL             string
 	Driver          string
+	Path            string
 	Host            string
 	Port            string
 	User            string
@@ -44,6 +49,63 @@ type DatabaseConfig struct {
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
+	QueryTimeout    time.Duration
+}
+
+// ScraperConfig holds page fetching and headless browser settings
+type ScraperConfig struct {
+	Headless    bool
+	Debug       bool
+	Fetcher     string
+	FetchDir    string
+	ChromePath  string
+	MaxTabs     int
+	PageTimeout time.Duration
+	// A failed fetch is tried up to RetryAttempts times in total when the
+	// failure is a timeout or a block; delays start at RetryBaseDelay and
+	// double up to RetryMaxDelay
+	RetryAttempts  int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// Requests to one host are limited to RequestsPerMinute (after a burst
+	// of RequestBurst) and spaced at least MinRequestInterval apart
+	RequestsPerMinute  int
+	RequestBurst       int
+	MinRequestInterval time.Duration
+	// RespectRobots skips pages disallowed by robots.txt and honours its
+	// Crawl-delay, using the group for RobotsAgent
+	RespectRobots bool
+	RobotsAgent   string
+}
+
+// JobsConfig holds settings for the background scrape job workers
+type JobsConfig struct {
+	Workers   int
+	QueueSize int
+}
+
+// ArchiveConfig selects where the raw HTML of fetched pages is kept: a
+// directory ("file"), the database ("postgres") or nowhere ("none")
+type ArchiveConfig struct {
+	Backend string
+	Dir     string
+}
+
+// SchedulerConfig holds the recurring scrape schedules. A schedule left
+// empty is disabled.
+type SchedulerConfig struct {
+	Enabled     bool
+	Timezone    string
+	Competition string
+	Season      string
+	// DiscoverCron re-crawls the season's gameweek listings
+	DiscoverCron string
+	// ScrapeDueCron scrapes pending fixtures that kicked off ScrapeDelay ago
+	ScrapeDueCron string
+	ScrapeDelay   time.Duration
+	// RescrapeCron scrapes fixtures again within RescrapeWindow of kick-off
+	RescrapeCron   string
+	RescrapeWindow time.Duration
 }
 
 // Load loads configuration from environment variables
@@ -65,6 +127,7 @@ func Load() *Config {
 		Database: DatabaseConfig{
 			URL:             getEnv("DATABASE_URL", ""),
 			Driver:          getEnv("DB_DRIVER", "postgres"),
+			Path:            getEnv("DB_PATH", "football.db"),
 			Host:            getEnv("DB_HOST", "localhost"),
 			Port:            getEnv("DB_PORT", "5432"),
 			User:            getEnv("DB_USER", "postgres"),
@@ -74,6 +137,45 @@ func Load() *Config {
 			MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
 			MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 25),
 			ConnMaxLifetime: getDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+			QueryTimeout:    getDuration("DB_QUERY_TIMEOUT", 5*time.Second),
+		},
+		Scraper: ScraperConfig{
+			Headless:    getEnvAsBool("SCRAPER_HEADLESS", true),
+			Debug:       getEnvAsBool("SCRAPER_DEBUG", false),
+			Fetcher:     getEnv("SCRAPER_FETCHER", "chrome"),
+			FetchDir:    getEnv("SCRAPER_FETCH_DIR", ""),
+			ChromePath:  getEnv("CHROME_PATH", ""),
+			MaxTabs:     getEnvAsInt("SCRAPER_MAX_TABS", 2),
+			PageTimeout: getDuration("SCRAPER_PAGE_TIMEOUT", 60*time.Second),
+
+			RetryAttempts:  getEnvAsInt("SCRAPER_RETRY_ATTEMPTS", 3),
+			RetryBaseDelay: getDuration("SCRAPER_RETRY_BASE_DELAY", 2*time.Second),
+			RetryMaxDelay:  getDuration("SCRAPER_RETRY_MAX_DELAY", 30*time.Second),
+
+			RequestsPerMinute:  getEnvAsInt("SCRAPER_REQUESTS_PER_MINUTE", 20),
+			RequestBurst:       getEnvAsInt("SCRAPER_REQUEST_BURST", 2),
+			MinRequestInterval: getDuration("SCRAPER_MIN_REQUEST_INTERVAL", 2*time.Second),
+			RespectRobots:      getEnvAsBool("SCRAPER_RESPECT_ROBOTS", true),
+			RobotsAgent:        getEnv("SCRAPER_ROBOTS_AGENT", "football-stats"),
+		},
+		Jobs: JobsConfig{
+			Workers:   getEnvAsInt("JOBS_WORKERS", 2),
+			QueueSize: getEnvAsInt("JOBS_QUEUE_SIZE", 100),
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:        getEnvAsBool("SCHEDULER_ENABLED", false),
+			Timezone:       getEnv("SCHEDULER_TIMEZONE", "Europe/London"),
+			Competition:    getEnv("SCHEDULER_COMPETITION", "premier-league"),
+			Season:         getEnv("SCHEDULER_SEASON", ""),
+			DiscoverCron:   getEnv("SCHEDULER_DISCOVER_CRON", "0 6 * * *"),
+			ScrapeDueCron:  getEnv("SCHEDULER_SCRAPE_DUE_CRON", "*/30 * * * *"),
+			ScrapeDelay:    getDuration("SCHEDULER_SCRAPE_DELAY", 3*time.Hour),
+			RescrapeCron:   getEnv("SCHEDULER_RESCRAPE_CRON", "0 5 * * *"),
+			RescrapeWindow: getDuration("SCHEDULER_RESCRAPE_WINDOW", 48*time.Hour),
+		},
+		Archive: ArchiveConfig{
+			Backend: getEnv("ARCHIVE_BACKEND", "file"),
+			Dir:     getEnv("ARCHIVE_DIR", "./data/archive"),
 		},
 	}
 
@@ -98,6 +200,14 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
 func getDuration(key string, defaultValue time.Duration) time.Duration {
 	valueStr := getEnv(key, "")
 	if value, err := time.ParseDuration(valueStr); err == nil {
@@ -108,11 +218,47 @@ func getDuration(key string, defaultValue time.Duration) time.Duration {
 
 // validate ensures required configuration is present
 func validate(cfg *Config) {
-	if cfg.Database.Host == "" || cfg.Database.Name == "" {
-		log.Fatal("database configuration is invalid")
+	switch cfg.Database.Driver {
+	case "postgres":
+		if cfg.Database.URL == "" && (cfg.Database.Host == "" || cfg.Database.Name == "") {
+			log.Fatal("database configuration is invalid")
+		}
+	case "sqlite":
+		if cfg.Database.Path == "" {
+			log.Fatal("DB_PATH must be set for the sqlite driver")
+		}
+	case "memory":
+	default:
+		log.Fatal("DB_DRIVER must be postgres, sqlite or memory")
 	}
 
 	if cfg.Server.Port == "" {
 		log.Fatal("server port must be set")
 	}
+
+	if cfg.Scraper.MaxTabs < 1 {
+		log.Fatal("SCRAPER_MAX_TABS must be at least 1")
+	}
+
+	if cfg.Scraper.RetryAttempts < 1 {
+		log.Fatal("SCRAPER_RETRY_ATTEMPTS must be at least 1")
+	}
+
+	if cfg.Scraper.RequestsPerMinute < 0 || cfg.Scraper.RequestBurst < 1 {
+		log.Fatal("SCRAPER_REQUESTS_PER_MINUTE must not be negative and SCRAPER_REQUEST_BURST must be at least 1")
+	}
+
+	if cfg.Jobs.Workers < 1 || cfg.Jobs.QueueSize < 1 {
+		log.Fatal("JOBS_WORKERS and JOBS_QUEUE_SIZE must be at least 1")
+	}
+
+	switch cfg.Archive.Backend {
+	case "file":
+		if cfg.Archive.Dir == "" {
+			log.Fatal("ARCHIVE_DIR must not be empty when ARCHIVE_BACKEND is file")
+		}
+	case "postgres", "none":
+	default:
+		log.Fatal("ARCHIVE_BACKEND must be file, postgres or none")
+	}
 }
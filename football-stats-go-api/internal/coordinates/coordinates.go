@@ -0,0 +1,157 @@
+package coordinates
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Canonical pitch dimensions in metres
+const (
+	PitchLength = 105.0
+	PitchWidth  = 68.0
+)
+
+// Point is a position on the canonical pitch: X runs 0-105 m from the
+// shooting team's own goal line to the goal it attacks (left to right),
+// Y runs 0-68 m from the touchline on the attacker's left.
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Orientation is the direction of attack in a source drawing
+type Orientation int
+
+const (
+	// AttackingUp is a vertical drawing with the attacked goal line at the top
+	AttackingUp Orientation = iota
+	// AttackingRight is a horizontal drawing with the attacked goal line on the right
+	AttackingRight
+)
+
+// System describes how a source places shots on its own drawing of the pitch
+type System struct {
+	// Name is recorded on each shot so the original system stays known
+	Name string
+	// MinX, MinY, Width and Height are the source extent (an SVG viewBox, or 0 0 1 1 for fractions)
+	MinX   float64
+	MinY   float64
+	Width  float64
+	Height float64
+	// Orientation is the direction of attack in the source
+	Orientation Orientation
+	// Depth is how many metres of pitch length the source covers, measured
+	// back from the attacked goal line (52.5 for a half-pitch map)
+	Depth float64
+	// FlipAcross mirrors the across-pitch axis for sources that measure it from the attacker's right
+	FlipAcross bool
+}
+
+// XGStatShotMap is the half-pitch SVG drawn by xgstat.com: viewBox
+// "0 0 68 52.5" with the attacked goal line along the top edge
+var XGStatShotMap = System{
+	Name:        "xgstat_svg",
+	Width:       PitchWidth,
+	Height:      PitchLength / 2,
+	Orientation: AttackingUp,
+	Depth:       PitchLength / 2,
+}
+
+// UnderstatPitch is the full pitch used by understat.com's shot data: X and Y
+// are fractions, X towards the attacked goal and Y from the attacker's right
+var UnderstatPitch = System{
+	Name:        "understat",
+	Width:       1,
+	Height:      1,
+	Orientation: AttackingRight,
+	FlipAcross:  true,
+}
+
+// StatsBombPitch is the 120x80 yard pitch of StatsBomb event data: every
+// team attacks left to right and Y grows from the attacker's left touchline
+var StatsBombPitch = System{
+	Name:        "statsbomb",
+	Width:       120,
+	Height:      80,
+	Orientation: AttackingRight,
+}
+
+// WithViewBox returns a copy of the system using the extent of an SVG viewBox attribute
+func (s System) WithViewBox(viewBox string) (System, error) {
+	fields := strings.Fields(strings.ReplaceAll(viewBox, ",", " "))
+	if len(fields) != 4 {
+		return s, fmt.Errorf("invalid viewBox %q", viewBox)
+	}
+
+	values := make([]float64, 4)
+	for i, field := range fields {
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return s, fmt.Errorf("invalid viewBox %q: %w", viewBox, err)
+		}
+		values[i] = v
+	}
+	if values[2] <= 0 || values[3] <= 0 {
+		return s, fmt.Errorf("invalid viewBox %q: empty extent", viewBox)
+	}
+
+	s.MinX, s.MinY, s.Width, s.Height = values[0], values[1], values[2], values[3]
+	return s, nil
+}
+
+// Normalize converts a point in the source system to the canonical pitch.
+// Results are clamped to the pitch so markers drawn over a line stay on it.
+func (s System) Normalize(x, y float64) Point {
+	var along, across float64 // both as a fraction of the source extent
+
+	switch s.Orientation {
+	case AttackingUp:
+		// distance from the attacked goal line grows downwards
+		along = 1 - (y-s.MinY)/s.Height
+		across = (x - s.MinX) / s.Width
+	case AttackingRight:
+		along = (x - s.MinX) / s.Width
+		across = (y - s.MinY) / s.Height
+	}
+
+	if s.FlipAcross {
+		across = 1 - across
+	}
+
+	depth := s.Depth
+	if depth == 0 {
+		depth = PitchLength
+	}
+
+	return Point{
+		X: clamp(PitchLength-depth+along*depth, PitchLength),
+		Y: clamp(across*PitchWidth, PitchWidth),
+	}
+}
+
+// Percent returns the point scaled to 0-100 on both axes
+func (p Point) Percent() Point {
+	return Point{
+		X: round(p.X / PitchLength * 100),
+		Y: round(p.Y / PitchWidth * 100),
+	}
+}
+
+// FromPercent converts a 0-100 point back to metres
+func FromPercent(x, y float64) Point {
+	return Point{
+		X: x / 100 * PitchLength,
+		Y: y / 100 * PitchWidth,
+	}
+}
+
+func clamp(v, limit float64) float64 {
+	return math.Min(math.Max(v, 0), limit)
+}
+
+// round keeps three decimals, matching the DECIMAL(6, 3) shot columns
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"example/hello/internal/archive"
+)
+
+// SavePage stores a compressed copy of a fetched page unless the URL's
+// latest copy has the same content
+func (s *Service) SavePage(ctx context.Context, url string, fetchedAt time.Time, html string) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	data, err := archive.Compress(url, fetchedAt, html)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO page_archive (url, fetched_at, content_hash, size_bytes, html_gzip)
+		SELECT $1::text, $2::timestamp, $3::text, $4::integer, $5::bytea
+		WHERE NOT EXISTS (
+			SELECT 1 FROM (
+				SELECT content_hash FROM page_archive
+				WHERE url = $1
+				ORDER BY fetched_at DESC
+				LIMIT 1
+			) latest
+			WHERE latest.content_hash = $3
+		)
+	`, url, fetchedAt.UTC(), archive.Hash(html), len(html), data)
+	if err != nil {
+		return fmt.Errorf("failed to archive page: %w", err)
+	}
+	return nil
+}
+
+// LatestPages returns the most recent copy of each archived URL
+func (s *Service) LatestPages(ctx context.Context, filter archive.Filter) ([]archive.Entry, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var conditions []string
+	var args []any
+	if filter.URL != "" {
+		args = append(args, filter.URL)
+		conditions = append(conditions, fmt.Sprintf("url = $%d", len(args)))
+	}
+
+	query := `
+		SELECT DISTINCT ON (url) id, url, fetched_at, content_hash, size_bytes
+		FROM page_archive`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY url, fetched_at DESC"
+
+	// Since applies to the latest copy, not to every copy
+	if !filter.Since.IsZero() {
+		args = append(args, filter.Since)
+		query = fmt.Sprintf("SELECT * FROM (%s) latest WHERE fetched_at >= $%d ORDER BY url", query, len(args))
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archived pages: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []archive.Entry{}
+	for rows.Next() {
+		var entry archive.Entry
+		var id int64
+		if err := rows.Scan(&id, &entry.URL, &entry.FetchedAt, &entry.Hash, &entry.Size); err != nil {
+			return nil, fmt.Errorf("failed to scan archived page: %w", err)
+		}
+		entry.ID = strconv.FormatInt(id, 10)
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// LoadPage reads and decompresses an archived page
+func (s *Service) LoadPage(ctx context.Context, entry archive.Entry) (string, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var data []byte
+	err := s.db.QueryRowContext(ctx, `SELECT html_gzip FROM page_archive WHERE id = $1`, entry.ID).Scan(&data)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("archived page %s not found", entry.ID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load archived page: %w", err)
+	}
+
+	return archive.Decompress(data)
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"example/hello/internal/domain"
+)
+
+// SaveDiscoveredFixtures adds fixtures that are not stored yet and returns how
+// many were added. Fixtures already known keep their status.
+func (s *Service) SaveDiscoveredFixtures(ctx context.Context, fixtures []domain.DBDiscoveredFixture) (int, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	added := 0
+	for _, fixture := range fixtures {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO discovered_fixtures (
+				url, fixture_id, competition, season, gameweek, kickoff, status, discovered_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (url) DO NOTHING
+		`, fixture.URL, fixture.FixtureID, fixture.Competition, fixture.Season,
+			fixture.Gameweek, fixture.Kickoff, fixture.Status, fixture.DiscoveredAt,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert discovered fixture: %w", err)
+		}
+		if n, err := result.RowsAffected(); err == nil {
+			added += int(n)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return added, nil
+}
+
+// ListDiscoveredFixtures returns discovered fixtures matching the filter,
+// ordered by gameweek
+func (s *Service) ListDiscoveredFixtures(ctx context.Context, filter domain.DiscoveryFilter) ([]domain.DBDiscoveredFixture, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var conditions []string
+	var args []any
+	where := func(column string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+	if filter.Competition != "" {
+		where("competition", filter.Competition)
+	}
+	if filter.Season != "" {
+		where("season", filter.Season)
+	}
+	if filter.Gameweek > 0 {
+		where("gameweek", filter.Gameweek)
+	}
+	if filter.Status != "" {
+		where("status", filter.Status)
+	}
+	if !filter.KickoffAfter.IsZero() {
+		args = append(args, filter.KickoffAfter)
+		conditions = append(conditions, fmt.Sprintf("kickoff >= $%d", len(args)))
+	}
+	if !filter.KickoffBefore.IsZero() {
+		args = append(args, filter.KickoffBefore)
+		conditions = append(conditions, fmt.Sprintf("kickoff <= $%d", len(args)))
+	}
+
+	query := `
+		SELECT url, fixture_id, competition, season, gameweek, kickoff, status, error,
+			   discovered_at, scraped_at
+		FROM discovered_fixtures`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY season, gameweek, kickoff, id"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query discovered fixtures: %w", err)
+	}
+	defer rows.Close()
+
+	fixtures := []domain.DBDiscoveredFixture{}
+	for rows.Next() {
+		var fixture domain.DBDiscoveredFixture
+		var scrapeErr sql.NullString
+		var kickoff, scrapedAt sql.NullTime
+		err := rows.Scan(
+			&fixture.URL, &fixture.FixtureID, &fixture.Competition, &fixture.Season,
+			&fixture.Gameweek, &kickoff, &fixture.Status, &scrapeErr,
+			&fixture.DiscoveredAt, &scrapedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan discovered fixture: %w", err)
+		}
+		fixture.Error = scrapeErr.String
+		if kickoff.Valid {
+			fixture.Kickoff = &kickoff.Time
+		}
+		if scrapedAt.Valid {
+			fixture.ScrapedAt = &scrapedAt.Time
+		}
+		fixtures = append(fixtures, fixture)
+	}
+
+	return fixtures, rows.Err()
+}
+
+// RecordScrape marks a discovered fixture as scraped or failed, and takes the
+// exact kick-off from a scraped fixture. URLs that were never discovered are
+// ignored.
+func (s *Service) RecordScrape(ctx context.Context, url string, fixture *domain.DBXGStatFixture, scrapeErr error) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	status := domain.DiscoveryScraped
+	var message sql.NullString
+	if scrapeErr != nil {
+		status = domain.DiscoveryFailed
+		message = sql.NullString{String: scrapeErr.Error(), Valid: true}
+	}
+
+	var kickoff sql.NullTime
+	if fixture != nil && !fixture.Date.IsZero() {
+		kickoff = sql.NullTime{Time: fixture.Date.UTC(), Valid: true}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE discovered_fixtures
+		SET status = $2, error = $3, scraped_at = CURRENT_TIMESTAMP,
+			kickoff = COALESCE($4, kickoff)
+		WHERE url = $1
+	`, url, status, message, kickoff)
+	if err != nil {
+		return fmt.Errorf("failed to update discovered fixture: %w", err)
+	}
+	return nil
+}
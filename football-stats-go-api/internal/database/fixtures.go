@@ -0,0 +1,244 @@
+package database
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"example/hello/internal/domain"
+)
+
+// Page sizes of ListFixtures
+const (
+	DefaultFixtureLimit = 50
+	MaxFixtureLimit     = 200
+)
+
+// ErrInvalidCursor is returned for a cursor that was not a NextCursor of the
+// same sort order
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// fixtureSortColumns are the SQL expressions behind the fixture sort orders
+var fixtureSortColumns = map[string]string{
+	domain.FixtureSortDate:     "fixture_date",
+	domain.FixtureSortGameweek: "gameweek",
+	domain.FixtureSortXG:       "home_xg + away_xg",
+}
+
+// fixtureCursor is the position after the last fixture of a page: its sort
+// value and its unique (source, match_key) key, which breaks ties. Rows saved
+// before match keys can share a fixture ID, so the ID cannot break them.
+type fixtureCursor struct {
+	Sort   string    `json:"sort"`
+	Date   time.Time `json:"date"`
+	Value  float64   `json:"value,omitempty"`
+	Source string    `json:"source"`
+	Key    string    `json:"key"`
+}
+
+// fixtureOrder is a validated sort order, limit and cursor of a filter
+type fixtureOrder struct {
+	sort  string
+	key   string
+	desc  bool
+	limit int
+	after *fixtureCursor
+}
+
+// newFixtureOrder validates the filter's sort and decodes its cursor
+func newFixtureOrder(filter domain.FixtureFilter) (fixtureOrder, error) {
+	order := fixtureOrder{sort: filter.Sort, limit: filter.Limit}
+	if order.sort == "" {
+		order.sort = "-" + domain.FixtureSortDate
+	}
+	order.key = strings.TrimPrefix(order.sort, "-")
+	order.desc = order.key != order.sort
+	if _, ok := fixtureSortColumns[order.key]; !ok {
+		return order, fmt.Errorf("unknown fixture sort %q", filter.Sort)
+	}
+	if order.limit <= 0 {
+		order.limit = DefaultFixtureLimit
+	}
+	order.limit = min(order.limit, MaxFixtureLimit)
+
+	switch filter.Result {
+	case "", domain.ResultHome, domain.ResultAway, domain.ResultDraw:
+	case domain.ResultWin, domain.ResultLoss:
+		if filter.Team == "" {
+			return order, fmt.Errorf("result %s needs a team", filter.Result)
+		}
+	default:
+		return order, fmt.Errorf("unknown result %q", filter.Result)
+	}
+
+	if filter.Cursor != "" {
+		data, err := base64.RawURLEncoding.DecodeString(filter.Cursor)
+		if err != nil {
+			return order, ErrInvalidCursor
+		}
+		var cursor fixtureCursor
+		if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != order.sort || cursor.Key == "" {
+			return order, ErrInvalidCursor
+		}
+		order.after = &cursor
+	}
+	return order, nil
+}
+
+// value is the fixture's sort value; total xG is passed in as the backend
+// computed it so the next page's comparison matches exactly
+func (o fixtureOrder) value(fixture domain.DBFixtureSummary, totalXG float64) any {
+	switch o.key {
+	case domain.FixtureSortGameweek:
+		return fixture.Gameweek
+	case domain.FixtureSortXG:
+		return totalXG
+	default:
+		return fixture.Date
+	}
+}
+
+// cursorValue is the sort value stored in a cursor
+func (o fixtureOrder) cursorValue(cursor *fixtureCursor) any {
+	switch o.key {
+	case domain.FixtureSortGameweek:
+		return int(cursor.Value)
+	case domain.FixtureSortXG:
+		return cursor.Value
+	default:
+		// Dates are compared in UTC, as SQLite compares them as text
+		return cursor.Date.UTC()
+	}
+}
+
+// page trims fixtures, fetched with one extra, to the limit and sets the
+// cursor of the next page
+func (o fixtureOrder) page(fixtures []domain.DBFixtureSummary, totalXG []float64) *domain.FixturePage {
+	page := &domain.FixturePage{Fixtures: fixtures}
+	if len(fixtures) <= o.limit {
+		return page
+	}
+	page.Fixtures = fixtures[:o.limit]
+
+	last := page.Fixtures[o.limit-1]
+	cursor := fixtureCursor{Sort: o.sort, Source: last.Source, Key: last.MatchKey}
+	switch v := o.value(last, totalXG[o.limit-1]).(type) {
+	case time.Time:
+		cursor.Date = v
+	case int:
+		cursor.Value = float64(v)
+	case float64:
+		cursor.Value = v
+	}
+	data, _ := json.Marshal(cursor)
+	page.NextCursor = base64.RawURLEncoding.EncodeToString(data)
+	return page
+}
+
+// ListFixtures returns a page of fixtures matching the filter. Paging uses
+// the sort value and match key of the previous page's last fixture, so
+// fixtures saved meanwhile do not shift the pages.
+func (s *sqlStore) ListFixtures(ctx context.Context, filter domain.FixtureFilter) (*domain.FixturePage, error) {
+	order, err := newFixtureOrder(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var conditions []string
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if filter.Source != "" {
+		conditions = append(conditions, "source = "+arg(filter.Source))
+	}
+	if filter.Gameweek > 0 {
+		conditions = append(conditions, "gameweek = "+arg(filter.Gameweek))
+	}
+	var team string
+	if filter.Team != "" {
+		team = arg(filter.Team)
+		conditions = append(conditions, fmt.Sprintf("(LOWER(home_team) = LOWER(%[1]s) OR LOWER(away_team) = LOWER(%[1]s))", team))
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "fixture_date >= "+arg(filter.From.UTC()))
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "fixture_date < "+arg(filter.Until.UTC()))
+	}
+	if filter.MinXG > 0 {
+		conditions = append(conditions, "home_xg + away_xg >= "+arg(filter.MinXG))
+	}
+	if filter.MaxXG > 0 {
+		conditions = append(conditions, "home_xg + away_xg <= "+arg(filter.MaxXG))
+	}
+	switch filter.Result {
+	case domain.ResultHome:
+		conditions = append(conditions, "home_score > away_score")
+	case domain.ResultAway:
+		conditions = append(conditions, "home_score < away_score")
+	case domain.ResultDraw:
+		conditions = append(conditions, "home_score = away_score")
+	case domain.ResultWin:
+		conditions = append(conditions, fmt.Sprintf("(LOWER(home_team) = LOWER(%[1]s) AND home_score > away_score OR LOWER(away_team) = LOWER(%[1]s) AND away_score > home_score)", team))
+	case domain.ResultLoss:
+		conditions = append(conditions, fmt.Sprintf("(LOWER(home_team) = LOWER(%[1]s) AND home_score < away_score OR LOWER(away_team) = LOWER(%[1]s) AND away_score < home_score)", team))
+	}
+
+	column := fixtureSortColumns[order.key]
+	direction, op := "ASC", ">"
+	if order.desc {
+		direction, op = "DESC", "<"
+	}
+	if order.after != nil {
+		conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s %[3]s OR %[1]s = %[3]s AND (source, match_key) %[2]s (%[4]s, %[5]s))",
+			column, op, arg(order.cursorValue(order.after)), arg(order.after.Source), arg(order.after.Key)))
+	}
+
+	query := `
+		SELECT source, gameweek, fixture_id, match_key, fixture_date,
+			   home_team, away_team, home_score, away_score,
+			   home_xg, away_xg, home_xg + away_xg
+		FROM xgstat_fixtures`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %[1]s %[2]s, source %[2]s, match_key %[2]s LIMIT %[3]s", column, direction, arg(order.limit+1))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fixtures: %w", err)
+	}
+	defer rows.Close()
+
+	fixtures := []domain.DBFixtureSummary{}
+	var totalXG []float64
+	for rows.Next() {
+		var fixture domain.DBFixtureSummary
+		var total float64
+		err := rows.Scan(
+			&fixture.Source, &fixture.Gameweek, &fixture.ID, &fixture.MatchKey, &fixture.Date,
+			&fixture.HomeTeam, &fixture.AwayTeam,
+			&fixture.HomeScore, &fixture.AwayScore,
+			&fixture.HomeXG, &fixture.AwayXG, &total,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fixture: %w", err)
+		}
+		fixtures = append(fixtures, fixture)
+		totalXG = append(totalXG, total)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	return order.page(fixtures, totalXG), nil
+}
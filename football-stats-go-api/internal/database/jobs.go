@@ -0,0 +1,163 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"example/hello/internal/jobs"
+)
+
+// CreateJob inserts a new scrape job
+func (s *Service) CreateJob(ctx context.Context, job *jobs.Job) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO scrape_jobs (id, url, strict, status, batch, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, job.ID, job.URL, job.Strict, job.Status, job.Batch, job.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert job: %w", err)
+	}
+	return nil
+}
+
+// UpdateJob stores the status and result of a scrape job
+func (s *Service) UpdateJob(ctx context.Context, job *jobs.Job) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	fixture, err := marshalNullable(job.Fixture != nil, job.Fixture)
+	if err != nil {
+		return fmt.Errorf("failed to encode job fixture: %w", err)
+	}
+	report, err := marshalNullable(job.Report != nil, job.Report)
+	if err != nil {
+		return fmt.Errorf("failed to encode job report: %w", err)
+	}
+	queued, err := marshalNullable(job.Jobs != nil, job.Jobs)
+	if err != nil {
+		return fmt.Errorf("failed to encode queued jobs: %w", err)
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE scrape_jobs
+		SET status = $2, fixture = $3, report = $4, error = $5, error_type = $6,
+			started_at = $7, finished_at = $8, jobs = $9
+		WHERE id = $1
+	`, job.ID, job.Status, fixture, report,
+		sql.NullString{String: job.Error, Valid: job.Error != ""},
+		sql.NullString{String: job.ErrorType, Valid: job.ErrorType != ""},
+		job.StartedAt, job.FinishedAt, queued,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return jobs.ErrNotFound
+	}
+	return nil
+}
+
+// GetJob retrieves a scrape job by ID
+func (s *Service) GetJob(ctx context.Context, id string) (*jobs.Job, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, url, strict, status, batch, jobs, fixture, report, error, error_type,
+			   created_at, started_at, finished_at
+		FROM scrape_jobs
+		WHERE id = $1
+	`, id)
+
+	job, err := scanJob(row)
+	if err == sql.ErrNoRows {
+		return nil, jobs.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job: %w", err)
+	}
+	return job, nil
+}
+
+// ListUnfinishedJobs returns queued and running jobs, oldest first
+func (s *Service) ListUnfinishedJobs(ctx context.Context) ([]*jobs.Job, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, url, strict, status, batch, jobs, fixture, report, error, error_type,
+			   created_at, started_at, finished_at
+		FROM scrape_jobs
+		WHERE status IN ('queued', 'running')
+		ORDER BY created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var unfinished []*jobs.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		unfinished = append(unfinished, job)
+	}
+	return unfinished, rows.Err()
+}
+
+// scanJob reads a scrape_jobs row selected in column order
+func scanJob(row interface{ Scan(...any) error }) (*jobs.Job, error) {
+	var job jobs.Job
+	var queued, fixture, report []byte
+	var jobErr, errType sql.NullString
+	var startedAt, finishedAt sql.NullTime
+
+	err := row.Scan(
+		&job.ID, &job.URL, &job.Strict, &job.Status, &job.Batch, &queued,
+		&fixture, &report, &jobErr, &errType,
+		&job.CreatedAt, &startedAt, &finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	job.Error = jobErr.String
+	job.ErrorType = errType.String
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	if queued != nil {
+		if err := json.Unmarshal(queued, &job.Jobs); err != nil {
+			return nil, fmt.Errorf("failed to decode queued jobs: %w", err)
+		}
+	}
+	if fixture != nil {
+		if err := json.Unmarshal(fixture, &job.Fixture); err != nil {
+			return nil, fmt.Errorf("failed to decode job fixture: %w", err)
+		}
+	}
+	if report != nil {
+		if err := json.Unmarshal(report, &job.Report); err != nil {
+			return nil, fmt.Errorf("failed to decode job report: %w", err)
+		}
+	}
+	return &job, nil
+}
+
+// marshalNullable encodes v as JSON, or returns nil (SQL NULL) when !valid
+func marshalNullable(valid bool, v any) ([]byte, error) {
+	if !valid {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
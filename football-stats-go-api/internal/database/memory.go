@@ -0,0 +1,410 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"example/hello/internal/domain"
+)
+
+// Memory keeps fixtures in memory. Nothing survives a restart; it is meant
+// for development and tests.
+type Memory struct {
+	mu       sync.RWMutex
+	fixtures map[fixtureKey]*domain.DBXGStatFixture
+}
+
+// fixtureKey identifies a fixture like the source, match_key unique key
+type fixtureKey struct {
+	source string
+	key    string
+}
+
+// NewMemory creates an empty in-memory repository
+func NewMemory() *Memory {
+	return &Memory{
+		fixtures: make(map[fixtureKey]*domain.DBXGStatFixture),
+	}
+}
+
+// SaveXGStatFixture stores a copy of the fixture, replacing an earlier copy
+// of the same match from the same source
+func (m *Memory) SaveXGStatFixture(ctx context.Context, fixture *domain.DBXGStatFixture) error {
+	stored := copyFixture(fixture)
+	if stored.Source == "" {
+		stored.Source = domain.SourceXGStat
+	}
+	// Shots come back in the order the SQL repositories return them
+	sortShots(stored.HomeShots)
+	sortShots(stored.AwayShots)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fixtures[fixtureKey{stored.Source, stored.Key()}] = stored
+	return nil
+}
+
+// GetFixtureByID returns a copy of a stored fixture
+func (m *Memory) GetFixtureByID(ctx context.Context, source string, fixtureID int) (*domain.DBXGStatFixture, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var found []*domain.DBXGStatFixture
+	for key, fixture := range m.fixtures {
+		if key.source == source && fixture.ID == fixtureID {
+			found = append(found, fixture)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, ErrFixtureNotFound
+	case 1:
+		return copyFixture(found[0]), nil
+	}
+
+	keys := make([]string, len(found))
+	for i, fixture := range found {
+		keys[i] = fixture.Key()
+	}
+	sort.Strings(keys)
+	return nil, ambiguousFixture(keys)
+}
+
+// GetFixtureByKey returns a copy of a stored fixture
+func (m *Memory) GetFixtureByKey(ctx context.Context, source, key string) (*domain.DBXGStatFixture, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	fixture, ok := m.fixtures[fixtureKey{source, key}]
+	if !ok {
+		return nil, ErrFixtureNotFound
+	}
+	return copyFixture(fixture), nil
+}
+
+// Close does nothing; it is part of FixtureRepository
+func (m *Memory) Close() error {
+	return nil
+}
+
+// copyFixture copies a fixture and its shot slices so callers cannot change
+// the stored one
+func copyFixture(fixture *domain.DBXGStatFixture) *domain.DBXGStatFixture {
+	c := *fixture
+	c.HomeShots = append([]domain.DBXGStatShot{}, fixture.HomeShots...)
+	c.AwayShots = append([]domain.DBXGStatShot{}, fixture.AwayShots...)
+	return &c
+}
+
+// sortShots orders shots by minute with unknown minutes last, keeping the
+// saved order otherwise
+func sortShots(shots []domain.DBXGStatShot) {
+	sort.SliceStable(shots, func(i, j int) bool {
+		a, b := shots[i].Minute, shots[j].Minute
+		if a == 0 || b == 0 {
+			return a != 0 && b == 0
+		}
+		return a < b
+	})
+}
+
+// ListFixtures returns a page of fixtures matching the filter, ordered and
+// paged like the SQL repositories
+func (m *Memory) ListFixtures(ctx context.Context, filter domain.FixtureFilter) (*domain.FixturePage, error) {
+	order, err := newFixtureOrder(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	fixtures := []domain.DBFixtureSummary{}
+	for _, fixture := range m.fixtures {
+		if matchesFixtureFilter(fixture, filter) {
+			fixtures = append(fixtures, summarize(fixture))
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(fixtures, func(i, j int) bool {
+		return order.compare(fixtures[i], fixtures[j]) < 0
+	})
+	if order.after != nil {
+		// A fixture holding the cursor's sort value, whose total xG is Value
+		after := domain.DBFixtureSummary{Source: order.after.Source, MatchKey: order.after.Key, Date: order.after.Date, Gameweek: int(order.after.Value), HomeXG: order.after.Value}
+		start := sort.Search(len(fixtures), func(i int) bool {
+			return order.compare(fixtures[i], after) > 0
+		})
+		fixtures = fixtures[start:]
+	}
+	if len(fixtures) > order.limit+1 {
+		fixtures = fixtures[:order.limit+1]
+	}
+
+	totalXG := make([]float64, len(fixtures))
+	for i, fixture := range fixtures {
+		totalXG[i] = fixture.HomeXG + fixture.AwayXG
+	}
+	return order.page(fixtures, totalXG), nil
+}
+
+// compare orders two fixtures by the sort value, then by source and match key
+func (o fixtureOrder) compare(a, b domain.DBFixtureSummary) int {
+	c := 0
+	switch o.key {
+	case domain.FixtureSortGameweek:
+		c = cmp.Compare(a.Gameweek, b.Gameweek)
+	case domain.FixtureSortXG:
+		c = cmp.Compare(a.HomeXG+a.AwayXG, b.HomeXG+b.AwayXG)
+	default:
+		c = a.Date.Compare(b.Date)
+	}
+	if c == 0 {
+		c = cmp.Or(strings.Compare(a.Source, b.Source), strings.Compare(a.MatchKey, b.MatchKey))
+	}
+	if o.desc {
+		return -c
+	}
+	return c
+}
+
+// matchesFixtureFilter applies the filter's conditions to a fixture
+func matchesFixtureFilter(fixture *domain.DBXGStatFixture, filter domain.FixtureFilter) bool {
+	home := strings.EqualFold(fixture.HomeTeam, filter.Team)
+	away := strings.EqualFold(fixture.AwayTeam, filter.Team)
+	totalXG := fixture.HomeXG + fixture.AwayXG
+	switch {
+	case filter.Source != "" && fixture.Source != filter.Source,
+		filter.Gameweek > 0 && fixture.Gameweek != filter.Gameweek,
+		filter.Team != "" && !home && !away,
+		!filter.From.IsZero() && fixture.Date.Before(filter.From),
+		!filter.Until.IsZero() && !fixture.Date.Before(filter.Until),
+		filter.MinXG > 0 && totalXG < filter.MinXG,
+		filter.MaxXG > 0 && totalXG > filter.MaxXG:
+		return false
+	}
+
+	goalDiff := fixture.HomeScore - fixture.AwayScore
+	switch filter.Result {
+	case domain.ResultHome:
+		return goalDiff > 0
+	case domain.ResultAway:
+		return goalDiff < 0
+	case domain.ResultDraw:
+		return goalDiff == 0
+	case domain.ResultWin:
+		return home && goalDiff > 0 || away && goalDiff < 0
+	case domain.ResultLoss:
+		return home && goalDiff < 0 || away && goalDiff > 0
+	}
+	return true
+}
+
+// summarize drops a fixture's shots
+func summarize(fixture *domain.DBXGStatFixture) domain.DBFixtureSummary {
+	return domain.DBFixtureSummary{
+		Source:    fixture.Source,
+		Gameweek:  fixture.Gameweek,
+		ID:        fixture.ID,
+		MatchKey:  fixture.Key(),
+		Date:      fixture.Date,
+		HomeTeam:  fixture.HomeTeam,
+		AwayTeam:  fixture.AwayTeam,
+		HomeScore: fixture.HomeScore,
+		AwayScore: fixture.AwayScore,
+		HomeXG:    fixture.HomeXG,
+		AwayXG:    fixture.AwayXG,
+	}
+}
+
+// TeamSummary aggregates the team's fixtures like the SQL repositories
+func (m *Memory) TeamSummary(ctx context.Context, team string, filter domain.TeamFilter) (*domain.TeamSummary, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	summary := domain.TeamSummary{Source: filter.Source}
+	for _, fixture := range m.fixtures {
+		home := strings.EqualFold(fixture.HomeTeam, team)
+		if !home && !strings.EqualFold(fixture.AwayTeam, team) {
+			continue
+		}
+		switch {
+		case filter.Source != "" && fixture.Source != filter.Source,
+			!filter.From.IsZero() && fixture.Date.Before(filter.From),
+			!filter.Until.IsZero() && !fixture.Date.Before(filter.Until),
+			filter.FromGameweek > 0 && fixture.Gameweek < filter.FromGameweek,
+			filter.ToGameweek > 0 && fixture.Gameweek > filter.ToGameweek:
+			continue
+		}
+
+		name, goalsFor, goalsAgainst := fixture.AwayTeam, fixture.AwayScore, fixture.HomeScore
+		xgFor, xgAgainst := fixture.AwayXG, fixture.HomeXG
+		shots, shotsAgainst := fixture.AwayShots, fixture.HomeShots
+		if home {
+			name, goalsFor, goalsAgainst = fixture.HomeTeam, fixture.HomeScore, fixture.AwayScore
+			xgFor, xgAgainst = fixture.HomeXG, fixture.AwayXG
+			shots, shotsAgainst = fixture.HomeShots, fixture.AwayShots
+		}
+
+		summary.Team = max(summary.Team, name)
+		summary.Matches++
+		summary.GoalsFor += goalsFor
+		summary.GoalsAgainst += goalsAgainst
+		summary.XGFor += xgFor
+		summary.XGAgainst += xgAgainst
+		summary.Shots += len(shots)
+		summary.ShotsOnTarget += countOnTarget(shots)
+		summary.ShotsAgainst += len(shotsAgainst)
+		summary.ShotsOnTargetAgainst += countOnTarget(shotsAgainst)
+	}
+	if summary.Matches == 0 {
+		return nil, ErrTeamNotFound
+	}
+
+	finishTeamSummary(&summary)
+	return &summary, nil
+}
+
+// countOnTarget counts goals and saved shots
+func countOnTarget(shots []domain.DBXGStatShot) int {
+	n := 0
+	for _, shot := range shots {
+		if shot.IsGoal || shot.ShotType == "on_target" {
+			n++
+		}
+	}
+	return n
+}
+
+// PlayerShots returns the player's shots in match order
+func (m *Memory) PlayerShots(ctx context.Context, player string, filter domain.PlayerFilter) ([]domain.PlayerShot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	shots := []domain.PlayerShot{}
+	for _, fixture := range m.fixtures {
+		if !matchesPlayerFixture(fixture, filter) {
+			continue
+		}
+		for _, side := range []struct {
+			team, opponent string
+			shots          []domain.DBXGStatShot
+		}{
+			{fixture.HomeTeam, fixture.AwayTeam, fixture.HomeShots},
+			{fixture.AwayTeam, fixture.HomeTeam, fixture.AwayShots},
+		} {
+			if filter.Team != "" && !strings.EqualFold(side.team, filter.Team) {
+				continue
+			}
+			for _, shot := range side.shots {
+				if shot.PlayerName != player {
+					continue
+				}
+				shots = append(shots, domain.PlayerShot{
+					Source:       fixture.Source,
+					FixtureID:    fixture.ID,
+					Gameweek:     fixture.Gameweek,
+					Date:         fixture.Date,
+					Team:         side.team,
+					Opponent:     side.opponent,
+					DBXGStatShot: shot,
+				})
+			}
+		}
+	}
+
+	// Fixtures are unordered in the map; shots of a fixture stay in minute order
+	sort.SliceStable(shots, func(i, j int) bool {
+		a, b := shots[i], shots[j]
+		if c := cmp.Or(a.Date.Compare(b.Date), strings.Compare(a.Source, b.Source), cmp.Compare(a.FixtureID, b.FixtureID)); c != 0 {
+			return c < 0
+		}
+		return a.Minute != 0 && (b.Minute == 0 || a.Minute < b.Minute)
+	})
+	return shots, nil
+}
+
+// PlayerSummary aggregates the player's shots like the SQL repositories
+func (m *Memory) PlayerSummary(ctx context.Context, player string, filter domain.PlayerFilter) (*domain.PlayerSummary, error) {
+	shots, err := m.PlayerShots(ctx, player, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(shots) == 0 {
+		return nil, ErrPlayerNotFound
+	}
+
+	summary := newPlayerSummary(player, filter)
+	byType := map[string]*domain.ShotTypeTotals{}
+	type matchID struct {
+		source string
+		id     int
+	}
+	fixtures := map[matchID]bool{}
+	var distance float64
+	var measured int
+	for _, shot := range shots {
+		totals, ok := byType[shot.ShotType]
+		if !ok {
+			totals = &domain.ShotTypeTotals{ShotType: shot.ShotType}
+			byType[shot.ShotType] = totals
+		}
+		totals.Shots++
+		totals.XG += shot.XG
+		if shot.IsGoal {
+			totals.Goals++
+		}
+		if shot.Zone != "" {
+			distance += shot.Distance
+			measured++
+		}
+		fixtures[matchID{shot.Source, shot.FixtureID}] = true
+	}
+
+	for _, totals := range byType {
+		summary.ShotTypes = append(summary.ShotTypes, *totals)
+	}
+	sort.Slice(summary.ShotTypes, func(i, j int) bool {
+		return summary.ShotTypes[i].ShotType < summary.ShotTypes[j].ShotType
+	})
+	summary.Matches = len(fixtures)
+
+	finishPlayerSummary(summary, distance, measured)
+	return summary, nil
+}
+
+// matchesPlayerFixture applies the filter's fixture conditions
+func matchesPlayerFixture(fixture *domain.DBXGStatFixture, filter domain.PlayerFilter) bool {
+	switch {
+	case filter.Source != "" && fixture.Source != filter.Source,
+		!filter.From.IsZero() && fixture.Date.Before(filter.From),
+		!filter.Until.IsZero() && !fixture.Date.Before(filter.Until):
+		return false
+	}
+	return true
+}
+
+// SeasonFixtures returns copies of the fixtures in a gameweek range, ordered
+// by date
+func (m *Memory) SeasonFixtures(ctx context.Context, filter domain.SeasonFilter) ([]domain.DBXGStatFixture, error) {
+	m.mu.RLock()
+	fixtures := []domain.DBXGStatFixture{}
+	for _, fixture := range m.fixtures {
+		switch {
+		case filter.Source != "" && fixture.Source != filter.Source,
+			filter.FromGameweek > 0 && fixture.Gameweek < filter.FromGameweek,
+			filter.ToGameweek > 0 && fixture.Gameweek > filter.ToGameweek:
+			continue
+		}
+		fixtures = append(fixtures, *copyFixture(fixture))
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(fixtures, func(i, j int) bool {
+		a, b := fixtures[i], fixtures[j]
+		return cmp.Or(a.Date.Compare(b.Date), strings.Compare(a.Source, b.Source), cmp.Compare(a.ID, b.ID)) < 0
+	})
+	return fixtures, nil
+}
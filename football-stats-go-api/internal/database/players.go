@@ -0,0 +1,165 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"example/hello/internal/domain"
+)
+
+// ErrPlayerNotFound is returned when no shot of the player matches the filter
+var ErrPlayerNotFound = errors.New("player not found")
+
+// playerConditions selects the player's shots, s, and their fixtures, f.
+// The player name is matched exactly so the player_name index is used.
+func playerConditions(player string, filter domain.PlayerFilter) (string, []any) {
+	args := []any{player}
+	conditions := []string{"s.player_name = $1"}
+	where := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Source != "" {
+		where("f.source = $%d", filter.Source)
+	}
+	if filter.Team != "" {
+		where("LOWER(CASE WHEN s.team_type = 'home' THEN f.home_team ELSE f.away_team END) = LOWER($%d)", filter.Team)
+	}
+	if !filter.From.IsZero() {
+		where("f.fixture_date >= $%d", filter.From.UTC())
+	}
+	if !filter.Until.IsZero() {
+		where("f.fixture_date < $%d", filter.Until.UTC())
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// PlayerShots returns the player's shots in match order
+func (s *sqlStore) PlayerShots(ctx context.Context, player string, filter domain.PlayerFilter) ([]domain.PlayerShot, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	conditions, args := playerConditions(player, filter)
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+shotColumns+`,
+			   f.source, f.fixture_id, f.gameweek, f.fixture_date, f.home_team, f.away_team
+		FROM xgstat_shots s
+		JOIN xgstat_fixtures f ON f.id = s.fixture_id
+		WHERE `+conditions+`
+		ORDER BY f.fixture_date, f.source, f.fixture_id, s.minute NULLS LAST, s.id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query player shots: %w", err)
+	}
+	defer rows.Close()
+
+	shots := []domain.PlayerShot{}
+	for rows.Next() {
+		var shot domain.PlayerShot
+		var homeTeam, awayTeam string
+		var teamType string
+		shot.DBXGStatShot, teamType, err = scanShot(rows,
+			&shot.Source, &shot.FixtureID, &shot.Gameweek, &shot.Date, &homeTeam, &awayTeam)
+		if err != nil {
+			return nil, err
+		}
+		shot.Team, shot.Opponent = homeTeam, awayTeam
+		if teamType != "home" {
+			shot.Team, shot.Opponent = awayTeam, homeTeam
+		}
+		shots = append(shots, shot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read player shots: %w", err)
+	}
+	return shots, nil
+}
+
+// PlayerSummary aggregates the player's shots by shot type in SQL
+func (s *sqlStore) PlayerSummary(ctx context.Context, player string, filter domain.PlayerFilter) (*domain.PlayerSummary, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	conditions, args := playerConditions(player, filter)
+	rows, err := s.db.QueryContext(ctx, `
+		WITH player_shots AS (
+			SELECT s.fixture_id, COALESCE(s.shot_type, '') AS shot_type, s.is_goal, s.xg, s.distance
+			FROM xgstat_shots s
+			JOIN xgstat_fixtures f ON f.id = s.fixture_id
+			WHERE `+conditions+`
+		)
+		SELECT shot_type, COUNT(*),
+			   SUM(CASE WHEN is_goal THEN 1 ELSE 0 END), SUM(xg),
+			   COALESCE(SUM(distance), 0), COUNT(distance),
+			   (SELECT COUNT(DISTINCT fixture_id) FROM player_shots)
+		FROM player_shots
+		GROUP BY shot_type
+		ORDER BY shot_type
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query player summary: %w", err)
+	}
+	defer rows.Close()
+
+	summary := newPlayerSummary(player, filter)
+	var distance float64
+	var measured int
+	for rows.Next() {
+		var totals domain.ShotTypeTotals
+		var typeDistance float64
+		var typeMeasured int
+		err := rows.Scan(&totals.ShotType, &totals.Shots, &totals.Goals, &totals.XG,
+			&typeDistance, &typeMeasured, &summary.Matches)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan player summary: %w", err)
+		}
+		summary.ShotTypes = append(summary.ShotTypes, totals)
+		distance += typeDistance
+		measured += typeMeasured
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read player summary: %w", err)
+	}
+	if len(summary.ShotTypes) == 0 {
+		return nil, ErrPlayerNotFound
+	}
+
+	finishPlayerSummary(summary, distance, measured)
+	return summary, nil
+}
+
+// newPlayerSummary starts a summary of the filtered shots
+func newPlayerSummary(player string, filter domain.PlayerFilter) *domain.PlayerSummary {
+	return &domain.PlayerSummary{
+		Player:    player,
+		Source:    filter.Source,
+		Team:      filter.Team,
+		ShotTypes: []domain.ShotTypeTotals{},
+	}
+}
+
+// finishPlayerSummary totals the shot types and derives the rates. distance
+// is the summed distance of the measured shots.
+func finishPlayerSummary(summary *domain.PlayerSummary, distance float64, measured int) {
+	for i := range summary.ShotTypes {
+		totals := &summary.ShotTypes[i]
+		totals.XG = round3(totals.XG)
+		summary.Shots += totals.Shots
+		summary.Goals += totals.Goals
+		summary.XG += totals.XG
+	}
+	summary.XG = round3(summary.XG)
+	summary.GoalsMinusXG = round3(float64(summary.Goals) - summary.XG)
+	summary.ShotsPerMatch = round2(float64(summary.Shots) / float64(summary.Matches))
+	if measured > 0 {
+		summary.AverageDistance = round2(distance / float64(measured))
+	}
+}
+
+// round3 keeps three decimals like the shot xg column
+func round3(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
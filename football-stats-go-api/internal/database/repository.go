@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"example/hello/internal/config"
+	"example/hello/internal/domain"
+)
+
+var (
+	// ErrFixtureNotFound is returned when no fixture has the requested ID or key
+	ErrFixtureNotFound = errors.New("fixture not found")
+	// ErrFixtureAmbiguous is returned when more than one fixture has the
+	// requested ID, which xgstat rows saved before match keys can. It is
+	// wrapped with the fixtures' match keys, each of which can be looked up.
+	ErrFixtureAmbiguous = errors.New("more than one fixture has this ID")
+)
+
+// ambiguousFixture wraps ErrFixtureAmbiguous with the keys of the fixtures
+// sharing an ID
+func ambiguousFixture(keys []string) error {
+	return fmt.Errorf("%w: %s", ErrFixtureAmbiguous, strings.Join(keys, ", "))
+}
+
+// FixtureRepository stores scraped fixtures and their shots. Service
+// (Postgres), SQLite and Memory implement it, so the API can run without
+// Postgres.
+type FixtureRepository interface {
+	// SaveXGStatFixture saves a fixture, replacing an earlier copy of the
+	// same match from the same source
+	SaveXGStatFixture(ctx context.Context, fixture *domain.DBXGStatFixture) error
+	// GetFixtureByID returns a fixture by its source's ID, or
+	// ErrFixtureNotFound or ErrFixtureAmbiguous
+	GetFixtureByID(ctx context.Context, source string, fixtureID int) (*domain.DBXGStatFixture, error)
+	// GetFixtureByKey returns a fixture by its key (see DBXGStatFixture.Key),
+	// which is unique within a source, or ErrFixtureNotFound
+	GetFixtureByKey(ctx context.Context, source, key string) (*domain.DBXGStatFixture, error)
+	// ListFixtures returns a page of fixtures matching the filter. An unknown
+	// cursor is ErrInvalidCursor.
+	ListFixtures(ctx context.Context, filter domain.FixtureFilter) (*domain.FixturePage, error)
+	// TeamSummary aggregates a team's fixtures, or returns ErrTeamNotFound
+	// when none match
+	TeamSummary(ctx context.Context, team string, filter domain.TeamFilter) (*domain.TeamSummary, error)
+	// PlayerShots returns a player's shots in match order
+	PlayerShots(ctx context.Context, player string, filter domain.PlayerFilter) ([]domain.PlayerShot, error)
+	// PlayerSummary aggregates a player's shots, or returns ErrPlayerNotFound
+	// when none match
+	PlayerSummary(ctx context.Context, player string, filter domain.PlayerFilter) (*domain.PlayerSummary, error)
+	// SeasonFixtures returns the fixtures in a gameweek range with their
+	// shots, ordered by date
+	SeasonFixtures(ctx context.Context, filter domain.SeasonFilter) ([]domain.DBXGStatFixture, error)
+	Close() error
+}
+
+// Open returns the repository selected by DB_DRIVER: postgres, sqlite or
+// memory. Only the postgres one is a *Service, which also stores jobs,
+// discovered fixtures, scheduler runs and archived pages.
+func Open(cfg *config.Config) (FixtureRepository, error) {
+	// A failed constructor's nil pointer must not become a non-nil interface
+	switch cfg.Database.Driver {
+	case "postgres":
+		service, err := NewService(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return service, nil
+	case "sqlite":
+		store, err := NewSQLite(cfg.Database.Path, cfg.Database.QueryTimeout)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case "memory":
+		return NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", cfg.Database.Driver)
+	}
+}
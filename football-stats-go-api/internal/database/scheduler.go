@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// LastSlot returns the most recently claimed slot of a scheduled task, or the
+// zero time if the task never ran
+func (s *Service) LastSlot(ctx context.Context, name string) (time.Time, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var slot time.Time
+	err := s.db.QueryRowContext(ctx, `
+		SELECT last_slot FROM scheduler_runs WHERE name = $1
+	`, name).Scan(&slot)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query scheduler run: %w", err)
+	}
+	return slot, nil
+}
+
+// ClaimSlot records that a task is running for slot. It reports false when
+// that slot or a later one was claimed already, e.g. by another instance.
+func (s *Service) ClaimSlot(ctx context.Context, name string, slot time.Time) (bool, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO scheduler_runs (name, last_slot, last_started_at, last_status)
+		VALUES ($1, $2, $3, 'running')
+		ON CONFLICT (name) DO UPDATE SET
+			last_slot = EXCLUDED.last_slot,
+			last_started_at = EXCLUDED.last_started_at,
+			last_finished_at = NULL,
+			last_duration_ms = NULL,
+			last_status = 'running',
+			last_error = NULL
+		WHERE scheduler_runs.last_slot < EXCLUDED.last_slot
+	`, name, slot.UTC(), time.Now().UTC())
+	if err != nil {
+		return false, fmt.Errorf("failed to claim scheduler run: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim scheduler run: %w", err)
+	}
+	return n == 1, nil
+}
+
+// FinishSlot stores the outcome of a scheduled run
+func (s *Service) FinishSlot(ctx context.Context, name string, slot time.Time, duration time.Duration, runErr error) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	status := "succeeded"
+	var message sql.NullString
+	if runErr != nil {
+		status = "failed"
+		message = sql.NullString{String: runErr.Error(), Valid: true}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE scheduler_runs
+		SET last_finished_at = $3, last_duration_ms = $4, last_status = $5, last_error = $6
+		WHERE name = $1 AND last_slot = $2
+	`, name, slot.UTC(), time.Now().UTC(), duration.Milliseconds(), status, message)
+	if err != nil {
+		return fmt.Errorf("failed to record scheduler run: %w", err)
+	}
+	return nil
+}
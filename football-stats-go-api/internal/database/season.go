@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"example/hello/internal/domain"
+)
+
+// SeasonFixtures returns the fixtures in a gameweek range with their shots,
+// ordered by date. The shots of all fixtures are read in a single query.
+func (s *sqlStore) SeasonFixtures(ctx context.Context, filter domain.SeasonFilter) ([]domain.DBXGStatFixture, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var conditions []string
+	var args []any
+	where := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Source != "" {
+		where("f.source = $%d", filter.Source)
+	}
+	if filter.FromGameweek > 0 {
+		where("f.gameweek >= $%d", filter.FromGameweek)
+	}
+	if filter.ToGameweek > 0 {
+		where("f.gameweek <= $%d", filter.ToGameweek)
+	}
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT f.id, f.gameweek, f.fixture_id, f.fixture_date,
+			   f.home_team, f.away_team, f.home_score, f.away_score,
+			   f.home_xg, f.away_xg, f.source
+		FROM xgstat_fixtures f
+		`+whereClause+`
+		ORDER BY f.fixture_date, f.source, f.fixture_id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fixtures: %w", err)
+	}
+	defer rows.Close()
+
+	fixtures := []domain.DBXGStatFixture{}
+	byID := map[int]int{}
+	for rows.Next() {
+		fixture := domain.DBXGStatFixture{
+			HomeShots: []domain.DBXGStatShot{},
+			AwayShots: []domain.DBXGStatShot{},
+		}
+		var dbID int
+		err := rows.Scan(
+			&dbID, &fixture.Gameweek, &fixture.ID, &fixture.Date,
+			&fixture.HomeTeam, &fixture.AwayTeam,
+			&fixture.HomeScore, &fixture.AwayScore,
+			&fixture.HomeXG, &fixture.AwayXG, &fixture.Source,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fixture: %w", err)
+		}
+		byID[dbID] = len(fixtures)
+		fixtures = append(fixtures, fixture)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+	if len(fixtures) == 0 {
+		return fixtures, nil
+	}
+
+	shotRows, err := s.db.QueryContext(ctx, `
+		SELECT `+shotColumns+`, s.fixture_id
+		FROM xgstat_shots s
+		JOIN xgstat_fixtures f ON f.id = s.fixture_id
+		`+whereClause+`
+		ORDER BY s.fixture_id, s.minute NULLS LAST, s.id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shots: %w", err)
+	}
+	defer shotRows.Close()
+
+	for shotRows.Next() {
+		var dbID int
+		shot, teamType, err := scanShot(shotRows, &dbID)
+		if err != nil {
+			return nil, err
+		}
+		i, ok := byID[dbID]
+		if !ok {
+			continue // saved after the fixtures were read
+		}
+		if teamType == "home" {
+			fixtures[i].HomeShots = append(fixtures[i].HomeShots, shot)
+		} else {
+			fixtures[i].AwayShots = append(fixtures[i].AwayShots, shot)
+		}
+	}
+	if err := shotRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read shots: %w", err)
+	}
+
+	return fixtures, nil
+}
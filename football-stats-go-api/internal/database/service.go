@@ -1,8 +1,11 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
+	"time"
 
 	"example/hello/internal/config"
 	"example/hello/internal/domain"
@@ -10,9 +13,17 @@ import (
 	_ "github.com/lib/pq"
 )
 
-// Service handles database operations
+// Service handles database operations on Postgres. Besides fixtures it
+// stores scrape jobs, discovered fixtures, scheduler runs and archived pages.
 type Service struct {
-	db *sql.DB
+	sqlStore
+}
+
+// sqlStore holds the fixture queries shared by the Postgres and SQLite
+// repositories; their SQL is written to run on both
+type sqlStore struct {
+	db           *sql.DB
+	queryTimeout time.Duration
 }
 
 // NewService creates a new database service
@@ -44,22 +55,42 @@ func NewService(cfg *config.Config) (*Service, error) {
 	db.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
 
 	// Test connection
-	if err := db.Ping(); err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Database.QueryTimeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &Service{db: db}, nil
+	return &Service{sqlStore{db: db, queryTimeout: cfg.Database.QueryTimeout}}, nil
+}
+
+// withTimeout bounds a single database operation by the configured query timeout
+func (s *sqlStore) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.queryTimeout)
 }
 
 // Close closes the database connection
-func (s *Service) Close() error {
+func (s *sqlStore) Close() error {
 	return s.db.Close()
 }
 
-// SaveXGStatFixture saves a fixture and its shots to the database
-func (s *Service) SaveXGStatFixture(fixture *domain.DBXGStatFixture) error {
+// SaveXGStatFixture saves a fixture and its shots to the database, replacing
+// an earlier copy of the same match from the same source. Fixtures without a
+// source are stored as xgstat ones.
+func (s *sqlStore) SaveXGStatFixture(ctx context.Context, fixture *domain.DBXGStatFixture) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	source := fixture.Source
+	if source == "" {
+		source = domain.SourceXGStat
+	}
+
 	// Start a transaction
-	tx, err := s.db.Begin()
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -67,15 +98,17 @@ func (s *Service) SaveXGStatFixture(fixture *domain.DBXGStatFixture) error {
 
 	// Insert or update fixture
 	var fixtureID int
-	err = tx.QueryRow(`
+	err = tx.QueryRowContext(ctx, `
 		INSERT INTO xgstat_fixtures (
 			gameweek, fixture_id, fixture_date, 
 			home_team, away_team, 
 			home_score, away_score, 
-			home_xg, away_xg
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		ON CONFLICT (fixture_id, gameweek) 
+			home_xg, away_xg, source, match_key
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (source, match_key) 
 		DO UPDATE SET
+			fixture_id = EXCLUDED.fixture_id,
+			gameweek = EXCLUDED.gameweek,
 			fixture_date = EXCLUDED.fixture_date,
 			home_team = EXCLUDED.home_team,
 			away_team = EXCLUDED.away_team,
@@ -88,7 +121,7 @@ func (s *Service) SaveXGStatFixture(fixture *domain.DBXGStatFixture) error {
 	`, fixture.Gameweek, fixture.ID, fixture.Date,
 		fixture.HomeTeam, fixture.AwayTeam,
 		fixture.HomeScore, fixture.AwayScore,
-		fixture.HomeXG, fixture.AwayXG,
+		fixture.HomeXG, fixture.AwayXG, source, fixture.Key(),
 	).Scan(&fixtureID)
 
 	if err != nil {
@@ -96,14 +129,14 @@ func (s *Service) SaveXGStatFixture(fixture *domain.DBXGStatFixture) error {
 	}
 
 	// Delete existing shots for this fixture to avoid duplicates
-	_, err = tx.Exec("DELETE FROM xgstat_shots WHERE fixture_id = $1", fixtureID)
+	_, err = tx.ExecContext(ctx, "DELETE FROM xgstat_shots WHERE fixture_id = $1", fixtureID)
 	if err != nil {
 		return fmt.Errorf("failed to delete existing shots: %w", err)
 	}
 
 	// Insert home shots
 	for _, shot := range fixture.HomeShots {
-		err = s.insertShot(tx, fixtureID, shot, "home")
+		err = s.insertShot(ctx, tx, fixtureID, shot, "home")
 		if err != nil {
 			return fmt.Errorf("failed to insert home shot: %w", err)
 		}
@@ -111,7 +144,7 @@ func (s *Service) SaveXGStatFixture(fixture *domain.DBXGStatFixture) error {
 
 	// Insert away shots
 	for _, shot := range fixture.AwayShots {
-		err = s.insertShot(tx, fixtureID, shot, "away")
+		err = s.insertShot(ctx, tx, fixtureID, shot, "away")
 		if err != nil {
 			return fmt.Errorf("failed to insert away shot: %w", err)
 		}
@@ -125,50 +158,120 @@ func (s *Service) SaveXGStatFixture(fixture *domain.DBXGStatFixture) error {
 	return nil
 }
 
-// insertShot inserts a single shot record
-func (s *Service) insertShot(tx *sql.Tx, fixtureID int, shot domain.DBXGStatShot, teamType string) error {
-	_, err := tx.Exec(`
+// insertShot inserts a single shot record. An unknown player or minute is
+// stored as NULL rather than "" / 0, which the valid_minute check rejects.
+func (s *sqlStore) insertShot(ctx context.Context, tx *sql.Tx, fixtureID int, shot domain.DBXGStatShot, teamType string) error {
+	_, err := tx.ExecContext(ctx, `
 		INSERT INTO xgstat_shots (
 			fixture_id, x, y, xg, is_goal, 
-			shot_type, player_name, minute, team_type
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			shot_type, player_name, minute, team_type,
+			coordinate_system, distance, angle, zone, situation,
+			body_part, play_pattern
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 	`, fixtureID, shot.X, shot.Y, shot.XG, shot.IsGoal,
-		shot.ShotType, shot.PlayerName, shot.Minute, teamType,
+		shot.ShotType,
+		sql.NullString{String: shot.PlayerName, Valid: shot.PlayerName != ""},
+		sql.NullInt64{Int64: int64(shot.Minute), Valid: shot.Minute > 0},
+		teamType,
+		sql.NullString{String: shot.CoordinateSystem, Valid: shot.CoordinateSystem != ""},
+		sql.NullFloat64{Float64: shot.Distance, Valid: shot.Zone != ""},
+		sql.NullFloat64{Float64: shot.Angle, Valid: shot.Zone != ""},
+		sql.NullString{String: shot.Zone, Valid: shot.Zone != ""},
+		sql.NullString{String: shot.Situation, Valid: shot.Situation != ""},
+		sql.NullString{String: shot.BodyPart, Valid: shot.BodyPart != ""},
+		sql.NullString{String: shot.PlayPattern, Valid: shot.PlayPattern != ""},
 	)
 	return err
 }
 
-// GetFixtureByID retrieves a fixture with its shots by fixture ID
-func (s *Service) GetFixtureByID(fixtureID int) (*domain.DBXGStatFixture, error) {
-	var fixture domain.DBXGStatFixture
+// GetFixtureByID retrieves a fixture with its shots by the source's fixture
+// ID. Rows saved before fixtures were keyed on match_key can share an xgstat
+// ID, which is ErrFixtureAmbiguous.
+func (s *sqlStore) GetFixtureByID(ctx context.Context, source string, fixtureID int) (*domain.DBXGStatFixture, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT match_key
+		FROM xgstat_fixtures
+		WHERE source = $1 AND fixture_id = $2
+		ORDER BY match_key
+	`, source, fixtureID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fixture: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan fixture: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	switch len(keys) {
+	case 0:
+		return nil, ErrFixtureNotFound
+	case 1:
+		return s.getFixture(ctx, source, keys[0])
+	default:
+		return nil, ambiguousFixture(keys)
+	}
+}
+
+// GetFixtureByKey retrieves a fixture with its shots by its match key, or
+// its ID for sources without one
+func (s *sqlStore) GetFixtureByKey(ctx context.Context, source, key string) (*domain.DBXGStatFixture, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	return s.getFixture(ctx, source, key)
+}
+
+// getFixture reads the fixture stored under source and match_key
+func (s *sqlStore) getFixture(ctx context.Context, source, key string) (*domain.DBXGStatFixture, error) {
+	fixture := domain.DBXGStatFixture{
+		HomeShots: []domain.DBXGStatShot{},
+		AwayShots: []domain.DBXGStatShot{},
+	}
 	var dbID int
+	var matchKey string
 
-	err := s.db.QueryRow(`
+	err := s.db.QueryRowContext(ctx, `
 		SELECT id, gameweek, fixture_id, fixture_date,
 			   home_team, away_team, home_score, away_score,
-			   home_xg, away_xg
+			   home_xg, away_xg, source, match_key
 		FROM xgstat_fixtures
-		WHERE fixture_id = $1
-	`, fixtureID).Scan(
+		WHERE source = $1 AND match_key = $2
+	`, source, key).Scan(
 		&dbID, &fixture.Gameweek, &fixture.ID, &fixture.Date,
 		&fixture.HomeTeam, &fixture.AwayTeam,
 		&fixture.HomeScore, &fixture.AwayScore,
-		&fixture.HomeXG, &fixture.AwayXG,
+		&fixture.HomeXG, &fixture.AwayXG, &fixture.Source, &matchKey,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("fixture not found")
+		return nil, ErrFixtureNotFound
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to query fixture: %w", err)
 	}
+	// A key that is just the ID was filled in by Key, not set by the source
+	if matchKey != strconv.Itoa(fixture.ID) {
+		fixture.MatchKey = matchKey
+	}
 
 	// Get shots
-	rows, err := s.db.Query(`
-		SELECT x, y, xg, is_goal, shot_type, player_name, minute, team_type
-		FROM xgstat_shots
-		WHERE fixture_id = $1
-		ORDER BY minute
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+shotColumns+`
+		FROM xgstat_shots s
+		WHERE s.fixture_id = $1
+		ORDER BY s.minute NULLS LAST, s.id
 	`, dbID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query shots: %w", err)
@@ -176,22 +279,59 @@ func (s *Service) GetFixtureByID(fixtureID int) (*domain.DBXGStatFixture, error)
 	defer rows.Close()
 
 	for rows.Next() {
-		var shot domain.DBXGStatShot
-		var teamType string
-		err := rows.Scan(
-			&shot.X, &shot.Y, &shot.XG, &shot.IsGoal,
-			&shot.ShotType, &shot.PlayerName, &shot.Minute, &teamType,
-		)
+		shot, teamType, err := scanShot(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan shot: %w", err)
+			return nil, err
 		}
-
 		if teamType == "home" {
 			fixture.HomeShots = append(fixture.HomeShots, shot)
 		} else {
 			fixture.AwayShots = append(fixture.AwayShots, shot)
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read shots: %w", err)
+	}
 
 	return &fixture, nil
 }
+
+// shotColumns are the xgstat_shots columns, aliased s, read by scanShot
+const shotColumns = `s.x, s.y, s.xg, s.is_goal, s.shot_type, s.player_name, s.minute, s.team_type,
+			   s.coordinate_system, s.distance, s.angle, s.zone, s.situation,
+			   s.body_part, s.play_pattern`
+
+// scanShot scans a row starting with shotColumns into a shot and its team
+// type; dest receives the columns selected after them
+func scanShot(rows *sql.Rows, dest ...any) (domain.DBXGStatShot, string, error) {
+	var shot domain.DBXGStatShot
+	var teamType string
+	var playerName sql.NullString
+	var minute sql.NullInt64
+	var coordinateSystem, zone, situation, bodyPart, playPattern sql.NullString
+	var distance, angle sql.NullFloat64
+	err := rows.Scan(append([]any{
+		&shot.X, &shot.Y, &shot.XG, &shot.IsGoal,
+		&shot.ShotType, &playerName, &minute, &teamType,
+		&coordinateSystem, &distance, &angle, &zone, &situation,
+		&bodyPart, &playPattern,
+	}, dest...)...)
+	if err != nil {
+		return shot, "", fmt.Errorf("failed to scan shot: %w", err)
+	}
+	shot.PlayerName = playerName.String
+	shot.Minute = int(minute.Int64)
+	shot.CoordinateSystem = coordinateSystem.String
+	shot.Distance = distance.Float64
+	shot.Angle = angle.Float64
+	shot.Zone = zone.String
+	shot.Situation = situation.String
+	shot.BodyPart = bodyPart.String
+	shot.PlayPattern = playPattern.String
+
+	// Normalized shots saved before geometry was stored get it derived on read
+	if !zone.Valid && coordinateSystem.Valid {
+		shot.DeriveGeometry()
+	}
+	return shot, teamType, nil
+}
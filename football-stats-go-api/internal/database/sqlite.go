@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+//go:embed sqlite_schema.sql
+var sqliteSchema string
+
+// SQLite stores fixtures in an embedded SQLite database file, for running
+// the API without a Postgres server
+type SQLite struct {
+	sqlStore
+}
+
+// NewSQLite opens the SQLite database at path, creating it and its tables
+// if needed
+func NewSQLite(path string, queryTimeout time.Duration) (*SQLite, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// SQLite allows a single writer; one connection avoids lock errors
+	db.SetMaxOpenConns(1)
+
+	store := &SQLite{sqlStore{db: db, queryTimeout: queryTimeout}}
+	ctx, cancel := store.withTimeout(context.Background())
+	defer cancel()
+	if err := checkSQLiteKey(ctx, db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if _, err := db.ExecContext(ctx, sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return store, nil
+}
+
+// checkSQLiteKey rejects database files created before fixtures were keyed on
+// (source, match_key). Their table is keyed on (source, fixture_id), which
+// SQLite cannot change in place, and xgstat fixture IDs in them were the
+// match's day of the month.
+func checkSQLiteKey(ctx context.Context, db *sql.DB) error {
+	var tables, keyColumns int
+	err := db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'xgstat_fixtures'),
+			(SELECT COUNT(*) FROM pragma_table_info('xgstat_fixtures') WHERE name = 'match_key')
+	`).Scan(&tables, &keyColumns)
+	if err != nil {
+		return fmt.Errorf("failed to read schema: %w", err)
+	}
+	if tables > 0 && keyColumns == 0 {
+		return fmt.Errorf("database predates fixture match keys; delete it and re-import the fixtures, e.g. with cmd/reparse")
+	}
+	return nil
+}
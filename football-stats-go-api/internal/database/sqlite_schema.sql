@@ -0,0 +1,54 @@
+-- Fixture tables for the SQLite repository. They mirror xgstat_fixtures and
+-- xgstat_shots as built by the Postgres migrations, so the shared queries
+-- run on both; keep them in step when a migration changes those tables.
+CREATE TABLE IF NOT EXISTS xgstat_fixtures (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source VARCHAR(20) NOT NULL DEFAULT 'xgstat',
+    gameweek INT NOT NULL,
+    fixture_id BIGINT NOT NULL,
+    fixture_date TIMESTAMP NOT NULL,
+    home_team VARCHAR(255) NOT NULL,
+    away_team VARCHAR(255) NOT NULL,
+    home_score INT NOT NULL,
+    away_score INT NOT NULL,
+    home_xg DECIMAL(5, 2) NOT NULL,
+    away_xg DECIMAL(5, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    match_key VARCHAR(255) NOT NULL,
+    UNIQUE (source, match_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_xgstat_fixtures_gameweek ON xgstat_fixtures(gameweek);
+CREATE INDEX IF NOT EXISTS idx_xgstat_fixtures_date ON xgstat_fixtures(fixture_date);
+CREATE INDEX IF NOT EXISTS idx_xgstat_fixtures_source_fixture_id ON xgstat_fixtures(source, fixture_id);
+
+CREATE TABLE IF NOT EXISTS xgstat_shots (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    fixture_id INT NOT NULL REFERENCES xgstat_fixtures(id) ON DELETE CASCADE,
+    x DECIMAL(6, 3) NOT NULL,
+    y DECIMAL(6, 3) NOT NULL,
+    xg DECIMAL(5, 3) NOT NULL,
+    is_goal BOOLEAN NOT NULL,
+    shot_type VARCHAR(100),
+    player_name VARCHAR(255),
+    minute INT,
+    team_type VARCHAR(10) NOT NULL CHECK (team_type IN ('home', 'away')),
+    coordinate_system VARCHAR(50),
+    distance DECIMAL(6, 3),
+    angle DECIMAL(6, 3),
+    zone VARCHAR(50),
+    situation VARCHAR(30),
+    body_part VARCHAR(20),
+    play_pattern VARCHAR(30),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT valid_coordinates CHECK (x >= 0 AND x <= 100 AND y >= 0 AND y <= 100),
+    CONSTRAINT valid_xg CHECK (xg >= 0 AND xg <= 1),
+    CONSTRAINT valid_minute CHECK (minute > 0 AND minute <= 120),
+    CONSTRAINT valid_angle CHECK (angle >= 0 AND angle <= 180)
+);
+
+CREATE INDEX IF NOT EXISTS idx_xgstat_shots_fixture_id ON xgstat_shots(fixture_id);
+CREATE INDEX IF NOT EXISTS idx_xgstat_shots_player ON xgstat_shots(player_name);
+CREATE INDEX IF NOT EXISTS idx_xgstat_shots_is_goal ON xgstat_shots(is_goal);
+CREATE INDEX IF NOT EXISTS idx_xgstat_shots_zone ON xgstat_shots(zone);
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"example/hello/internal/domain"
+)
+
+// ErrTeamNotFound is returned when no fixture of the team matches the filter
+var ErrTeamNotFound = errors.New("team not found")
+
+// TeamSummary aggregates the team's fixtures and shots in one query. The
+// team is matched ignoring case against either side of a fixture.
+func (s *sqlStore) TeamSummary(ctx context.Context, team string, filter domain.TeamFilter) (*domain.TeamSummary, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	args := []any{team}
+	conditions := []string{"(LOWER(home_team) = LOWER($1) OR LOWER(away_team) = LOWER($1))"}
+	where := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Source != "" {
+		where("source = $%d", filter.Source)
+	}
+	if !filter.From.IsZero() {
+		where("fixture_date >= $%d", filter.From.UTC())
+	}
+	if !filter.Until.IsZero() {
+		where("fixture_date < $%d", filter.Until.UTC())
+	}
+	if filter.FromGameweek > 0 {
+		where("gameweek >= $%d", filter.FromGameweek)
+	}
+	if filter.ToGameweek > 0 {
+		where("gameweek <= $%d", filter.ToGameweek)
+	}
+
+	// team_fixtures turns each fixture around to the team's side
+	query := `
+		WITH team_fixtures AS (
+			SELECT id,
+				   CASE WHEN LOWER(home_team) = LOWER($1) THEN 'home' ELSE 'away' END AS side,
+				   CASE WHEN LOWER(home_team) = LOWER($1) THEN home_team ELSE away_team END AS team,
+				   CASE WHEN LOWER(home_team) = LOWER($1) THEN home_score ELSE away_score END AS goals_for,
+				   CASE WHEN LOWER(home_team) = LOWER($1) THEN away_score ELSE home_score END AS goals_against,
+				   CASE WHEN LOWER(home_team) = LOWER($1) THEN home_xg ELSE away_xg END AS xg_for,
+				   CASE WHEN LOWER(home_team) = LOWER($1) THEN away_xg ELSE home_xg END AS xg_against
+			FROM xgstat_fixtures
+			WHERE ` + strings.Join(conditions, " AND ") + `
+		),
+		fixture_totals AS (
+			SELECT COUNT(*) AS matches, MAX(team) AS team,
+				   COALESCE(SUM(goals_for), 0) AS goals_for,
+				   COALESCE(SUM(goals_against), 0) AS goals_against,
+				   COALESCE(SUM(xg_for), 0) AS xg_for,
+				   COALESCE(SUM(xg_against), 0) AS xg_against
+			FROM team_fixtures
+		),
+		shot_totals AS (
+			SELECT COALESCE(SUM(CASE WHEN s.team_type = f.side THEN 1 ELSE 0 END), 0) AS shots,
+				   COALESCE(SUM(CASE WHEN s.team_type = f.side AND (s.is_goal OR s.shot_type = 'on_target') THEN 1 ELSE 0 END), 0) AS shots_on_target,
+				   COALESCE(SUM(CASE WHEN s.team_type <> f.side THEN 1 ELSE 0 END), 0) AS shots_against,
+				   COALESCE(SUM(CASE WHEN s.team_type <> f.side AND (s.is_goal OR s.shot_type = 'on_target') THEN 1 ELSE 0 END), 0) AS shots_on_target_against
+			FROM xgstat_shots s
+			JOIN team_fixtures f ON s.fixture_id = f.id
+		)
+		SELECT matches, COALESCE(team, ''), goals_for, goals_against, xg_for, xg_against,
+			   shots, shots_on_target, shots_against, shots_on_target_against
+		FROM fixture_totals, shot_totals`
+
+	summary := domain.TeamSummary{Source: filter.Source}
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(
+		&summary.Matches, &summary.Team,
+		&summary.GoalsFor, &summary.GoalsAgainst,
+		&summary.XGFor, &summary.XGAgainst,
+		&summary.Shots, &summary.ShotsOnTarget,
+		&summary.ShotsAgainst, &summary.ShotsOnTargetAgainst,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team summary: %w", err)
+	}
+	if summary.Matches == 0 {
+		return nil, ErrTeamNotFound
+	}
+
+	finishTeamSummary(&summary)
+	return &summary, nil
+}
+
+// finishTeamSummary derives the xG difference and per-90 rates from the totals
+func finishTeamSummary(summary *domain.TeamSummary) {
+	summary.XGFor = round2(summary.XGFor)
+	summary.XGAgainst = round2(summary.XGAgainst)
+	summary.XGDifference = round2(summary.XGFor - summary.XGAgainst)
+
+	per90 := func(total float64) float64 {
+		return round2(total / float64(summary.Matches))
+	}
+	summary.Per90 = domain.TeamRates{
+		GoalsFor:      per90(float64(summary.GoalsFor)),
+		GoalsAgainst:  per90(float64(summary.GoalsAgainst)),
+		XGFor:         per90(summary.XGFor),
+		XGAgainst:     per90(summary.XGAgainst),
+		Shots:         per90(float64(summary.Shots)),
+		ShotsOnTarget: per90(float64(summary.ShotsOnTarget)),
+	}
+}
+
+// round2 keeps two decimals like the fixture xg columns
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -1,20 +1,75 @@
 package domain
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
 
+// Sources fixtures are scraped or imported from
+const (
+	SourceXGStat    = "xgstat"
+	SourceUnderstat = "understat"
+	SourceStatsBomb = "statsbomb"
+)
+
+// KnownSource reports whether source is one fixtures are stored under
+func KnownSource(source string) bool {
+	return source == SourceXGStat || source == SourceUnderstat || source == SourceStatsBomb
+}
+
+// SourceShotDetail reports whether a source's shots name their player and
+// carry a minute and an xG. xgstat.com publishes only the location and
+// outcome of each shot, with players and xG totalled per team.
+func SourceShotDetail(source string) bool {
+	return source == SourceUnderstat || source == SourceStatsBomb
+}
+
+// Shot situations; sources that do not report one leave Situation empty
+const (
+	SituationOpenPlay       = "open_play"
+	SituationCorner         = "corner"
+	SituationSetPiece       = "set_piece"
+	SituationDirectFreeKick = "direct_free_kick"
+	SituationPenalty        = "penalty"
+)
+
+// Body parts a shot was taken with
+const (
+	BodyPartRightFoot = "right_foot"
+	BodyPartLeftFoot  = "left_foot"
+	BodyPartHead      = "head"
+	BodyPartOther     = "other"
+)
+
+// DBXGStatShot is a single shot. X and Y are on the canonical 0-100 pitch
+// (see package coordinates): attacking left to right, X towards the goal.
+// Distance (metres), Angle (degrees between the posts) and Zone are set by DeriveGeometry.
 type DBXGStatShot struct {
-	X          float64 `json:"x"`
-	Y          float64 `json:"y"`
-	XG         float64 `json:"xg"`
-	IsGoal     bool    `json:"is_goal"`
-	ShotType   string  `json:"shot_type"`
-	PlayerName string  `json:"player_name"`
-	Minute     int     `json:"minute"`
+	X                float64 `json:"x"`
+	Y                float64 `json:"y"`
+	XG               float64 `json:"xg"`
+	IsGoal           bool    `json:"is_goal"`
+	ShotType         string  `json:"shot_type"`
+	PlayerName       string  `json:"player_name"`
+	Minute           int     `json:"minute"`
+	Situation        string  `json:"situation,omitempty"`
+	BodyPart         string  `json:"body_part,omitempty"`
+	PlayPattern      string  `json:"play_pattern,omitempty"`
+	CoordinateSystem string  `json:"coordinate_system"`
+	Distance         float64 `json:"distance"`
+	Angle            float64 `json:"angle"`
+	Zone             string  `json:"zone"`
 }
 
+// DBXGStatFixture is a match with both teams' shots. ID is the match ID at
+// Source, so the same match can be stored once per source for comparison.
+// MatchKey is set by sources whose pages identify a match by something other
+// than a number, such as xgstat.com's URL slug.
 type DBXGStatFixture struct {
+	Source    string         `json:"source"`
 	Gameweek  int            `json:"gameweek"`
 	ID        int            `json:"id"`
+	MatchKey  string         `json:"match_key,omitempty"`
 	Date      time.Time      `json:"date"`
 	HomeTeam  string         `json:"home_team"`
 	AwayTeam  string         `json:"away_team"`
@@ -25,3 +80,199 @@ type DBXGStatFixture struct {
 	HomeShots []DBXGStatShot `json:"home_shots"`
 	AwayShots []DBXGStatShot `json:"away_shots"`
 }
+
+// Key returns what a stored fixture is keyed on together with its source:
+// MatchKey when set, otherwise the numeric ID
+func (f *DBXGStatFixture) Key() string {
+	if f.MatchKey != "" {
+		return f.MatchKey
+	}
+	return strconv.Itoa(f.ID)
+}
+
+// Discovered fixture statuses
+const (
+	DiscoveryPending = "pending"
+	DiscoveryScraped = "scraped"
+	DiscoveryFailed  = "failed"
+)
+
+// DBDiscoveredFixture is a match found on a listing page, waiting to be scraped.
+// Kickoff is the listed kick-off, or the end of the match day when only the
+// date is listed; a successful scrape replaces it with the page's kick-off.
+type DBDiscoveredFixture struct {
+	URL          string     `json:"url"`
+	FixtureID    int        `json:"fixture_id"`
+	Competition  string     `json:"competition"`
+	Season       string     `json:"season"`
+	Gameweek     int        `json:"gameweek"`
+	Kickoff      *time.Time `json:"kickoff,omitempty"`
+	Status       string     `json:"status"`
+	Error        string     `json:"error,omitempty"`
+	DiscoveredAt time.Time  `json:"discovered_at"`
+	ScrapedAt    *time.Time `json:"scraped_at,omitempty"`
+}
+
+// DiscoveryFilter selects discovered fixtures; zero values match everything
+type DiscoveryFilter struct {
+	Competition string
+	Season      string
+	Gameweek    int
+	Status      string
+	// KickoffAfter and KickoffBefore bound the kick-off time (inclusive).
+	// Fixtures without a known kick-off never match a bound.
+	KickoffAfter  time.Time
+	KickoffBefore time.Time
+	Limit         int
+}
+
+// Fixture sort orders; each may be prefixed with - for descending order
+const (
+	FixtureSortDate     = "date"
+	FixtureSortGameweek = "gameweek"
+	FixtureSortXG       = "xg"
+)
+
+// Fixture results. Win and loss are from the point of view of the filtered team.
+const (
+	ResultHome = "home"
+	ResultAway = "away"
+	ResultDraw = "draw"
+	ResultWin  = "win"
+	ResultLoss = "loss"
+)
+
+// FixtureFilter selects stored fixtures; zero values match everything
+type FixtureFilter struct {
+	Source   string
+	Gameweek int
+	// Team matches the home or away team, ignoring case
+	Team string
+	// From and Until bound the fixture date; From is inclusive, Until exclusive
+	From  time.Time
+	Until time.Time
+	// MinXG and MaxXG bound the match's total xG, home plus away
+	MinXG float64
+	MaxXG float64
+	// Result is home, away or draw, or win or loss when Team is set
+	Result string
+	// Sort is one of the fixture sort orders, newest first (-date) by default
+	Sort string
+	// Cursor is the NextCursor of the previous page, listed with the same sort
+	Cursor string
+	Limit  int
+}
+
+// DBFixtureSummary is a stored fixture without its shots. MatchKey is the
+// fixture's Key, unique within its source, even where ID is not.
+type DBFixtureSummary struct {
+	Source    string    `json:"source"`
+	Gameweek  int       `json:"gameweek"`
+	ID        int       `json:"id"`
+	MatchKey  string    `json:"match_key"`
+	Date      time.Time `json:"date"`
+	HomeTeam  string    `json:"home_team"`
+	AwayTeam  string    `json:"away_team"`
+	HomeScore int       `json:"home_score"`
+	AwayScore int       `json:"away_score"`
+	HomeXG    float64   `json:"home_xg"`
+	AwayXG    float64   `json:"away_xg"`
+}
+
+// FixturePage is one page of listed fixtures. NextCursor is empty on the last page.
+type FixturePage struct {
+	Fixtures   []DBFixtureSummary `json:"fixtures"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// TeamFilter selects the fixtures a team summary covers; zero values match everything
+type TeamFilter struct {
+	Source string
+	// From and Until bound the fixture date; From is inclusive, Until exclusive
+	From  time.Time
+	Until time.Time
+	// FromGameweek and ToGameweek bound the gameweek (inclusive)
+	FromGameweek int
+	ToGameweek   int
+}
+
+// TeamSummary aggregates a team's fixtures. Goals come from the scores, so
+// they include own goals; xG is the fixtures' team xG.
+type TeamSummary struct {
+	Team                 string    `json:"team"`
+	Source               string    `json:"source,omitempty"`
+	Matches              int       `json:"matches"`
+	GoalsFor             int       `json:"goals_for"`
+	GoalsAgainst         int       `json:"goals_against"`
+	XGFor                float64   `json:"xg_for"`
+	XGAgainst            float64   `json:"xg_against"`
+	XGDifference         float64   `json:"xg_difference"`
+	Shots                int       `json:"shots"`
+	ShotsOnTarget        int       `json:"shots_on_target"`
+	ShotsAgainst         int       `json:"shots_against"`
+	ShotsOnTargetAgainst int       `json:"shots_on_target_against"`
+	Per90                TeamRates `json:"per_90"`
+}
+
+// TeamRates are a team's averages per 90 minutes, counting every match as 90
+type TeamRates struct {
+	GoalsFor      float64 `json:"goals_for"`
+	GoalsAgainst  float64 `json:"goals_against"`
+	XGFor         float64 `json:"xg_for"`
+	XGAgainst     float64 `json:"xg_against"`
+	Shots         float64 `json:"shots"`
+	ShotsOnTarget float64 `json:"shots_on_target"`
+}
+
+// PlayerFilter selects the shots a player profile covers; zero values match everything
+type PlayerFilter struct {
+	Source string
+	// Team is the team the player shot for, ignoring case
+	Team string
+	// From and Until bound the fixture date; From is inclusive, Until exclusive
+	From  time.Time
+	Until time.Time
+}
+
+// PlayerShot is a shot with the fixture it was taken in
+type PlayerShot struct {
+	Source    string    `json:"source"`
+	FixtureID int       `json:"fixture_id"`
+	Gameweek  int       `json:"gameweek"`
+	Date      time.Time `json:"date"`
+	Team      string    `json:"team"`
+	Opponent  string    `json:"opponent"`
+	DBXGStatShot
+}
+
+// PlayerSummary is a player's shooting profile. Matches counts the fixtures
+// the player had a shot in; AverageDistance is over the shots with geometry.
+type PlayerSummary struct {
+	Player          string           `json:"player"`
+	Source          string           `json:"source,omitempty"`
+	Team            string           `json:"team,omitempty"`
+	Matches         int              `json:"matches"`
+	Shots           int              `json:"shots"`
+	Goals           int              `json:"goals"`
+	XG              float64          `json:"xg"`
+	GoalsMinusXG    float64          `json:"goals_minus_xg"`
+	ShotsPerMatch   float64          `json:"shots_per_match"`
+	AverageDistance float64          `json:"average_distance"`
+	ShotTypes       []ShotTypeTotals `json:"shot_types"`
+}
+
+// ShotTypeTotals are a player's shots of one shot type
+type ShotTypeTotals struct {
+	ShotType string  `json:"shot_type"`
+	Shots    int     `json:"shots"`
+	Goals    int     `json:"goals"`
+	XG       float64 `json:"xg"`
+}
+
+// SeasonFilter selects the fixtures of a league table; zero values match everything
+type SeasonFilter struct {
+	Source string
+	// FromGameweek and ToGameweek bound the gameweek (inclusive)
+	FromGameweek int
+	ToGameweek   int
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"math"
+
+	"example/hello/internal/coordinates"
+)
+
+// Shot zones, from closest to furthest from goal
+const (
+	ZoneSixYardBox         = "six_yard_box"
+	ZonePenaltyAreaCentral = "penalty_area_central"
+	ZonePenaltyAreaWide    = "penalty_area_wide"
+	ZoneOutsideBox         = "outside_box"
+)
+
+// Pitch markings in metres; widths are measured from the goal centre
+const (
+	halfGoalWidth        = 3.66
+	sixYardBoxDepth      = 5.5
+	sixYardBoxHalfWidth  = 9.16
+	penaltyAreaDepth     = 16.5
+	penaltyAreaHalfWidth = 20.16
+)
+
+// DeriveGeometry sets the shot's distance to the goal centre, the angle
+// between the posts as seen from the shot, and its zone. X and Y must
+// already be on the canonical 0-100 pitch.
+func (s *DBXGStatShot) DeriveGeometry() {
+	p := coordinates.FromPercent(s.X, s.Y)
+	dx := coordinates.PitchLength - p.X
+	dy := p.Y - coordinates.PitchWidth/2
+
+	s.Distance = round3(math.Hypot(dx, dy))
+	s.Angle = round3(postAngle(dx, dy) * 180 / math.Pi)
+	s.Zone = zoneFor(dx, math.Abs(dy))
+}
+
+// postAngle is the angle in radians subtended by the goal mouth from a point
+// dx metres in front of the goal line and dy metres off its centre
+func postAngle(dx, dy float64) float64 {
+	nearX, nearY := dx, dy-halfGoalWidth
+	farX, farY := dx, dy+halfGoalWidth
+
+	cross := nearX*farY - nearY*farX
+	dot := nearX*farX + nearY*farY
+	return math.Abs(math.Atan2(cross, dot))
+}
+
+// zoneFor classifies a shot dx metres from the goal line and absDy metres off its centre
+func zoneFor(dx, absDy float64) string {
+	switch {
+	case dx <= sixYardBoxDepth && absDy <= sixYardBoxHalfWidth:
+		return ZoneSixYardBox
+	case dx <= penaltyAreaDepth && absDy <= sixYardBoxHalfWidth:
+		return ZonePenaltyAreaCentral
+	case dx <= penaltyAreaDepth && absDy <= penaltyAreaHalfWidth:
+		return ZonePenaltyAreaWide
+	default:
+		return ZoneOutsideBox
+	}
+}
+
+func round3(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
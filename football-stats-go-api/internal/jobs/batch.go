@@ -0,0 +1,57 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+
+	"example/hello/internal/domain"
+	"example/hello/internal/scraper"
+)
+
+// BatchResult is the outcome of scraping one URL of a batch
+type BatchResult struct {
+	URL     string                  `json:"url"`
+	Status  Status                  `json:"status"`
+	Fixture *domain.DBXGStatFixture `json:"fixture,omitempty"`
+	Report  *scraper.ParseReport    `json:"report,omitempty"`
+	Error   string                  `json:"error,omitempty"`
+}
+
+// RunBatch scrapes every URL with at most concurrency scrapes in flight and
+// saves each fixture when saver is set. A failed URL is recorded in its result
+// and does not stop the others. Results are in the order of urls.
+func RunBatch(ctx context.Context, s Scraper, saver Saver, urls []string, strict bool, concurrency int) []BatchResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]BatchResult, len(urls))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, url := range urls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				results[i] = BatchResult{URL: url, Status: StatusFailed, Error: ctx.Err().Error()}
+				return
+			}
+			defer func() { <-slots }()
+
+			fixture, report, err := scrapeAndSave(ctx, s, saver, url, strict)
+			result := BatchResult{URL: url, Status: StatusSucceeded, Fixture: fixture, Report: report}
+			if err != nil {
+				result.Status = StatusFailed
+				result.Error = err.Error()
+			}
+			results[i] = result
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"example/hello/internal/domain"
+)
+
+// Discoverer lists the matches of a gameweek
+type Discoverer interface {
+	DiscoverGameweek(ctx context.Context, competition, season string, gameweek int) ([]domain.DBDiscoveredFixture, error)
+}
+
+// DiscoveryStore keeps discovered fixtures and their scrape status
+type DiscoveryStore interface {
+	// SaveDiscoveredFixtures adds fixtures that are not stored yet and returns
+	// how many were added. Known fixtures keep their status.
+	SaveDiscoveredFixtures(ctx context.Context, fixtures []domain.DBDiscoveredFixture) (int, error)
+	ListDiscoveredFixtures(ctx context.Context, filter domain.DiscoveryFilter) ([]domain.DBDiscoveredFixture, error)
+}
+
+// Recorder is told the outcome of each scrape. A Saver that also implements
+// Recorder gets every finished scrape, failed or not.
+type Recorder interface {
+	RecordScrape(ctx context.Context, url string, fixture *domain.DBXGStatFixture, scrapeErr error) error
+}
+
+// DiscoveryResult summarises a crawl of gameweek listing pages
+type DiscoveryResult struct {
+	Competition string   `json:"competition"`
+	Season      string   `json:"season"`
+	Gameweeks   int      `json:"gameweeks"`
+	Found       int      `json:"found"`
+	Added       int      `json:"added"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+// Discover crawls the listing pages of gameweeks from..to and stores every
+// match found. A gameweek that fails to load is recorded in the result and
+// does not stop the crawl.
+func Discover(ctx context.Context, d Discoverer, store DiscoveryStore, competition, season string, from, to int) DiscoveryResult {
+	result := DiscoveryResult{Competition: competition, Season: season}
+
+	for gameweek := from; gameweek <= to; gameweek++ {
+		if ctx.Err() != nil {
+			result.Errors = append(result.Errors, ctx.Err().Error())
+			break
+		}
+		result.Gameweeks++
+
+		fixtures, err := d.DiscoverGameweek(ctx, competition, season, gameweek)
+		if err != nil {
+			log.Printf("⚠️ Gameweek %d discovery failed: %v", gameweek, err)
+			result.Errors = append(result.Errors, fmt.Sprintf("gameweek %d: %v", gameweek, err))
+			continue
+		}
+		result.Found += len(fixtures)
+
+		added, err := store.SaveDiscoveredFixtures(ctx, fixtures)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("gameweek %d: %v", gameweek, err))
+			continue
+		}
+		result.Added += added
+	}
+
+	log.Printf("🔎 Discovered %d matches (%d new) in %s %s", result.Found, result.Added, competition, season)
+	return result
+}
+
+// PendingURLs returns the URLs of discovered fixtures that have not been scraped
+func PendingURLs(ctx context.Context, store DiscoveryStore, filter domain.DiscoveryFilter) ([]string, error) {
+	filter.Status = domain.DiscoveryPending
+	fixtures, err := store.ListDiscoveredFixtures(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, len(fixtures))
+	for i, fixture := range fixtures {
+		urls[i] = fixture.URL
+	}
+	return urls, nil
+}
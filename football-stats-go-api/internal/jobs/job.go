@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	"example/hello/internal/domain"
+	"example/hello/internal/scraper"
+)
+
+// Status is the lifecycle state of a scrape job
+type Status string
+
+// Job statuses. Queued and running jobs are unfinished and are picked up
+// again when the queue starts.
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+var (
+	// ErrNotFound is returned when no job has the requested ID
+	ErrNotFound = errors.New("job not found")
+	// ErrQueueFull is returned when a job cannot be accepted right now
+	ErrQueueFull = errors.New("job queue is full")
+	// ErrInterrupted fails a batch job that was still listing its pages when
+	// the process stopped
+	ErrInterrupted = errors.New("interrupted before every page was queued")
+)
+
+// Job is a single scrape of one match page, or a batch job that lists the
+// pages at URL and queues a scrape job for each
+type Job struct {
+	ID     string `json:"id"`
+	URL    string `json:"url"`
+	Strict bool   `json:"strict"`
+	Status Status `json:"status"`
+	Batch  bool   `json:"batch,omitempty"`
+	// Jobs are the IDs of the scrape jobs a batch job has queued
+	Jobs []string `json:"jobs,omitempty"`
+	// Fixture is set once the job has succeeded
+	Fixture *domain.DBXGStatFixture `json:"fixture,omitempty"`
+	// Report is set whenever the page was fetched, including failed parses
+	Report *scraper.ParseReport `json:"report,omitempty"`
+	Error  string               `json:"error,omitempty"`
+	// ErrorType classifies a failure as scraper.ErrorKind does; a strict
+	// scrape rejected for an incomplete report is "incomplete"
+	ErrorType  string     `json:"error_type,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Finished reports whether the job has reached a final state
+func (j *Job) Finished() bool {
+	return j.Status == StatusSucceeded || j.Status == StatusFailed
+}
+
+// Store persists jobs. Implementations must return ErrNotFound from GetJob
+// for unknown IDs.
+type Store interface {
+	CreateJob(ctx context.Context, job *Job) error
+	GetJob(ctx context.Context, id string) (*Job, error)
+	UpdateJob(ctx context.Context, job *Job) error
+	// ListUnfinishedJobs returns queued and running jobs, oldest first
+	ListUnfinishedJobs(ctx context.Context) ([]*Job, error)
+}
+
+// newJobID returns a random version 4 UUID
+func newJobID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryStore keeps jobs in process memory. It is used when no database is
+// configured, so jobs do not survive a restart.
+type MemoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]Job
+}
+
+// NewMemoryStore creates an empty in-memory job store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{jobs: make(map[string]Job)}
+}
+
+// CreateJob stores a new job
+func (s *MemoryStore) CreateJob(ctx context.Context, job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[job.ID] = *job
+	return nil
+}
+
+// GetJob returns a copy of the job with the given ID
+func (s *MemoryStore) GetJob(ctx context.Context, id string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &job, nil
+}
+
+// UpdateJob replaces the stored job
+func (s *MemoryStore) UpdateJob(ctx context.Context, job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[job.ID]; !ok {
+		return ErrNotFound
+	}
+	s.jobs[job.ID] = *job
+	return nil
+}
+
+// ListUnfinishedJobs returns queued and running jobs, oldest first
+func (s *MemoryStore) ListUnfinishedJobs(ctx context.Context) ([]*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var unfinished []*Job
+	for _, job := range s.jobs {
+		if !job.Finished() {
+			unfinished = append(unfinished, &job)
+		}
+	}
+	sort.Slice(unfinished, func(i, j int) bool {
+		return unfinished[i].CreatedAt.Before(unfinished[j].CreatedAt)
+	})
+	return unfinished, nil
+}
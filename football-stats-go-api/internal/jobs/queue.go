@@ -0,0 +1,347 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"example/hello/internal/domain"
+	"example/hello/internal/scraper"
+)
+
+// Scraper fetches and parses a single match page
+type Scraper interface {
+	ScrapeXGStatFixture(ctx context.Context, url string) (*domain.DBXGStatFixture, *scraper.ParseReport, error)
+}
+
+// Saver stores a successfully scraped fixture
+type Saver interface {
+	SaveXGStatFixture(ctx context.Context, fixture *domain.DBXGStatFixture) error
+}
+
+// Options configures the worker pool
+type Options struct {
+	Workers   int
+	QueueSize int
+}
+
+// Queue runs scrape jobs on a fixed pool of workers. Job state is kept in a
+// Store so callers can poll it, and so unfinished jobs can be resumed after
+// a restart.
+type Queue struct {
+	store   Store
+	scraper Scraper
+	saver   Saver
+	workers int
+
+	pending chan *Job
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewQueue creates a job queue. saver may be nil, in which case fixtures are
+// only returned on the job and not stored anywhere else.
+func NewQueue(store Store, scraper Scraper, saver Saver, opts Options) *Queue {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	if opts.QueueSize < 1 {
+		opts.QueueSize = 1
+	}
+
+	return &Queue{
+		store:   store,
+		scraper: scraper,
+		saver:   saver,
+		workers: opts.Workers,
+		pending: make(chan *Job, opts.QueueSize),
+	}
+}
+
+// Start launches the workers and re-queues jobs that were queued or running
+// when the process last stopped
+func (q *Queue) Start(ctx context.Context) error {
+	unfinished, err := q.store.ListUnfinishedJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load unfinished jobs: %w", err)
+	}
+
+	// A batch job's listing cannot be resumed, so the ones cut short fail
+	resumable := unfinished[:0]
+	for _, job := range unfinished {
+		if job.Batch {
+			q.finish(job, nil, nil, ErrInterrupted)
+		} else {
+			resumable = append(resumable, job)
+		}
+	}
+	unfinished = resumable
+
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+
+	if len(unfinished) > 0 {
+		log.Printf("🔁 Resuming %d unfinished scrape jobs", len(unfinished))
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			for _, job := range unfinished {
+				select {
+				case q.pending <- job:
+				case <-q.ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	return nil
+}
+
+// Stop cancels running scrapes and waits for the workers to exit. Jobs that
+// were interrupted stay unfinished and are resumed by the next Start.
+func (q *Queue) Stop() {
+	if q.cancel == nil {
+		return
+	}
+	q.cancel()
+	q.wg.Wait()
+}
+
+// Submit records a new job and queues it for a worker. It fails with
+// ErrQueueFull instead of waiting when the queue has no room.
+func (q *Queue) Submit(ctx context.Context, url string, strict bool) (*Job, error) {
+	return q.submit(ctx, url, strict, false)
+}
+
+// SubmitBlocking is like Submit but waits for room in the queue. It is meant
+// for background work that queues many jobs at once.
+func (q *Queue) SubmitBlocking(ctx context.Context, url string, strict bool) (*Job, error) {
+	return q.submit(ctx, url, strict, true)
+}
+
+// SubmitBatch records a batch job for the listing at url and runs it in the
+// background: list returns the pages to scrape, and a job is queued for each,
+// waiting for room in the queue. The batch job succeeds once every page is
+// queued.
+func (q *Queue) SubmitBatch(ctx context.Context, url string, strict bool, list func(ctx context.Context) ([]string, error)) (*Job, error) {
+	job, err := q.create(ctx, url, strict, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// The batch owns job from here on, so the caller gets a copy
+	accepted := *job
+	q.Go(func(ctx context.Context) {
+		q.runBatch(ctx, job, list)
+	})
+	return &accepted, nil
+}
+
+func (q *Queue) submit(ctx context.Context, url string, strict bool, wait bool) (*Job, error) {
+	job, err := q.create(ctx, url, strict, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// The worker owns job from here on, so the caller gets a copy
+	accepted := *job
+
+	if wait {
+		select {
+		case q.pending <- job:
+			return &accepted, nil
+		case <-ctx.Done():
+			// The job stays queued in the store and is resumed by the next Start
+			return nil, ctx.Err()
+		}
+	}
+
+	select {
+	case q.pending <- job:
+		return &accepted, nil
+	default:
+		q.finish(job, nil, nil, ErrQueueFull)
+		return nil, ErrQueueFull
+	}
+}
+
+// create records a new queued job
+func (q *Queue) create(ctx context.Context, url string, strict bool, batch bool) (*Job, error) {
+	job := &Job{
+		ID:        newJobID(),
+		URL:       url,
+		Strict:    strict,
+		Status:    StatusQueued,
+		Batch:     batch,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := q.store.CreateJob(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+	return job, nil
+}
+
+// Go runs fn in the background. fn's context is cancelled by Stop, which
+// waits for fn to return.
+func (q *Queue) Go(fn func(ctx context.Context)) {
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		fn(q.ctx)
+	}()
+}
+
+// Get returns the current state of a job
+func (q *Queue) Get(ctx context.Context, id string) (*Job, error) {
+	return q.store.GetJob(ctx, id)
+}
+
+// UnfinishedURLs returns the URLs of scrape jobs that are queued or running,
+// so callers can avoid queueing the same page twice
+func (q *Queue) UnfinishedURLs(ctx context.Context) (map[string]bool, error) {
+	unfinished, err := q.store.ListUnfinishedJobs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load unfinished jobs: %w", err)
+	}
+
+	urls := make(map[string]bool, len(unfinished))
+	for _, job := range unfinished {
+		if !job.Batch {
+			urls[job.URL] = true
+		}
+	}
+	return urls, nil
+}
+
+// work runs jobs until the queue is stopped
+func (q *Queue) work() {
+	defer q.wg.Done()
+
+	for {
+		select {
+		case job := <-q.pending:
+			q.run(job)
+		case <-q.ctx.Done():
+			return
+		}
+	}
+}
+
+// run scrapes the job's page and records the outcome
+func (q *Queue) run(job *Job) {
+	q.start(job)
+
+	fixture, report, err := scrapeAndSave(q.ctx, q.scraper, q.saver, job.URL, job.Strict)
+	if q.ctx.Err() != nil {
+		// Shutting down: leave the job running so it is resumed on restart
+		return
+	}
+
+	q.finish(job, fixture, report, err)
+}
+
+// runBatch lists the pages of a batch job and queues a scrape job for each
+func (q *Queue) runBatch(ctx context.Context, job *Job, list func(ctx context.Context) ([]string, error)) {
+	q.start(job)
+
+	urls, err := list(ctx)
+	if err == nil {
+		seen := make(map[string]bool)
+		for _, url := range urls {
+			if url == "" || seen[url] {
+				continue
+			}
+			seen[url] = true
+
+			queued, submitErr := q.SubmitBlocking(ctx, url, job.Strict)
+			if submitErr != nil {
+				err = submitErr
+				break
+			}
+			job.Jobs = append(job.Jobs, queued.ID)
+		}
+	}
+
+	if ctx.Err() != nil {
+		// Shutting down: pages already queued are resumed on restart, the rest
+		// of the listing is not
+		err = ErrInterrupted
+	}
+	q.finish(job, nil, nil, err)
+}
+
+// scrapeAndSave scrapes one page and saves the fixture when saver is set.
+// With strict, an incomplete parse is an error and nothing is saved. The
+// outcome is passed on when saver is also a Recorder.
+func scrapeAndSave(ctx context.Context, s Scraper, saver Saver, url string, strict bool) (*domain.DBXGStatFixture, *scraper.ParseReport, error) {
+	fixture, report, err := scrape(ctx, s, saver, url, strict)
+
+	// An interrupted scrape is neither a success nor a failure of the page
+	if recorder, ok := saver.(Recorder); ok && ctx.Err() == nil {
+		if recordErr := recorder.RecordScrape(ctx, url, fixture, err); recordErr != nil {
+			log.Printf("⚠️ Failed to record scrape of %s: %v", url, recordErr)
+		}
+	}
+
+	return fixture, report, err
+}
+
+// scrape fetches, checks and saves one page
+func scrape(ctx context.Context, s Scraper, saver Saver, url string, strict bool) (*domain.DBXGStatFixture, *scraper.ParseReport, error) {
+	fixture, report, err := s.ScrapeXGStatFixture(ctx, url)
+	if err != nil {
+		return nil, report, err
+	}
+
+	if strict && !report.Complete {
+		return nil, report, scraper.ErrIncomplete
+	}
+
+	if saver != nil {
+		if err := saver.SaveXGStatFixture(ctx, fixture); err != nil {
+			return nil, report, fmt.Errorf("failed to save data: %w", err)
+		}
+	}
+
+	return fixture, report, nil
+}
+
+// start marks a job as running
+func (q *Queue) start(job *Job) {
+	started := time.Now().UTC()
+	job.Status = StatusRunning
+	job.StartedAt = &started
+	if err := q.store.UpdateJob(context.Background(), job); err != nil {
+		log.Printf("⚠️ Failed to mark job %s as running: %v", job.ID, err)
+	}
+}
+
+// finish stores the final state of a job
+func (q *Queue) finish(job *Job, fixture *domain.DBXGStatFixture, report *scraper.ParseReport, err error) {
+	finished := time.Now().UTC()
+	job.FinishedAt = &finished
+	job.Report = report
+
+	if err != nil {
+		job.Status = StatusFailed
+		job.Error = err.Error()
+		job.ErrorType = scraper.ErrorKind(err)
+		log.Printf("❌ Scrape job %s failed: %v", job.ID, err)
+	} else {
+		job.Status = StatusSucceeded
+		job.Fixture = fixture
+	}
+
+	if err := q.store.UpdateJob(context.Background(), job); err != nil {
+		log.Printf("⚠️ Failed to record result of job %s: %v", job.ID, err)
+	}
+}
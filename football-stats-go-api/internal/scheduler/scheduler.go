@@ -0,0 +1,158 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Task is a named piece of work run on a cron schedule
+type Task struct {
+	Name     string
+	Schedule *Cron
+	Run      func(ctx context.Context) error
+}
+
+// StateStore persists when each task last ran. Claiming a slot is atomic, so
+// a slot runs once even across restarts or several API instances.
+type StateStore interface {
+	// LastSlot returns the most recently claimed slot of a task, or the zero
+	// time if it never ran
+	LastSlot(ctx context.Context, name string) (time.Time, error)
+	// ClaimSlot records that slot is being run and reports false if it (or a
+	// later slot) was already claimed
+	ClaimSlot(ctx context.Context, name string, slot time.Time) (bool, error)
+	// FinishSlot stores the outcome of a run
+	FinishSlot(ctx context.Context, name string, slot time.Time, duration time.Duration, runErr error) error
+}
+
+// Scheduler runs tasks on their schedules until its context is cancelled
+type Scheduler struct {
+	tasks    []Task
+	store    StateStore
+	location *time.Location
+	now      func() time.Time
+}
+
+// New creates a scheduler. Schedules are evaluated in location.
+func New(tasks []Task, store StateStore, location *time.Location) *Scheduler {
+	if location == nil {
+		location = time.UTC
+	}
+	return &Scheduler{
+		tasks:    tasks,
+		store:    store,
+		location: location,
+		now:      time.Now,
+	}
+}
+
+// Run starts every task and blocks until ctx is cancelled and all runs have
+// returned
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, task := range s.tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.loop(ctx, task)
+		}()
+	}
+	wg.Wait()
+}
+
+// loop runs one task at each of its slots
+func (s *Scheduler) loop(ctx context.Context, task Task) {
+	log.Printf("⏰ Scheduled %s: %s", task.Name, task.Schedule)
+
+	var attempted time.Time
+	for {
+		slot, err := s.nextSlot(ctx, task)
+		if err != nil {
+			log.Printf("⚠️ Scheduler could not load state of %s: %v", task.Name, err)
+			slot = task.Schedule.Next(s.now().In(s.location))
+		}
+		// A slot that could not be claimed is not retried straight away
+		if !attempted.IsZero() && !slot.After(attempted) {
+			slot = task.Schedule.Next(attempted)
+		}
+		if slot.IsZero() {
+			log.Printf("⚠️ Schedule of %s never fires, stopping it", task.Name)
+			return
+		}
+
+		if wait := slot.Sub(s.now()); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+
+		s.runSlot(ctx, task, slot)
+		attempted = slot
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// nextSlot returns the slot to run next. A slot missed while the process was
+// down is run once straight away; older missed slots are skipped.
+func (s *Scheduler) nextSlot(ctx context.Context, task Task) (time.Time, error) {
+	now := s.now().In(s.location)
+
+	last, err := s.store.LastSlot(ctx, task.Name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if last.IsZero() {
+		return task.Schedule.Next(now), nil
+	}
+
+	next := task.Schedule.Next(last.In(s.location))
+	if next.IsZero() || next.After(now) {
+		return next, nil
+	}
+
+	// Catch up with the latest slot that has already passed
+	for {
+		following := task.Schedule.Next(next)
+		if following.IsZero() || following.After(now) {
+			return next, nil
+		}
+		next = following
+	}
+}
+
+// runSlot claims a slot and runs the task for it
+func (s *Scheduler) runSlot(ctx context.Context, task Task, slot time.Time) {
+	claimed, err := s.store.ClaimSlot(ctx, task.Name, slot)
+	if err != nil {
+		log.Printf("⚠️ Scheduler could not claim %s at %s: %v", task.Name, slot.Format(time.RFC3339), err)
+		return
+	}
+	if !claimed {
+		log.Printf("⏭️ Skipping %s at %s, it already ran", task.Name, slot.Format(time.RFC3339))
+		return
+	}
+
+	log.Printf("▶️ Running %s (slot %s)", task.Name, slot.Format(time.RFC3339))
+	start := s.now()
+	runErr := task.Run(ctx)
+	duration := s.now().Sub(start)
+
+	if runErr != nil {
+		log.Printf("❌ %s failed after %s: %v", task.Name, duration.Round(time.Millisecond), runErr)
+	} else {
+		log.Printf("✅ %s finished in %s", task.Name, duration.Round(time.Millisecond))
+	}
+
+	// Record the outcome even when shutting down mid-run
+	if err := s.store.FinishSlot(context.Background(), task.Name, slot, duration, runErr); err != nil {
+		log.Printf("⚠️ Scheduler could not record %s: %v", task.Name, err)
+	}
+}
@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"example/hello/internal/config"
+	"example/hello/internal/domain"
+	"example/hello/internal/jobs"
+	"example/hello/internal/scraper"
+)
+
+// Task names, also used as keys in scheduler_runs
+const (
+	TaskDiscover       = "discover"
+	TaskScrapeDue      = "scrape_due"
+	TaskRescrapeRecent = "rescrape_recent"
+)
+
+// Submitter queues a scrape job, waiting for room in the queue
+type Submitter interface {
+	SubmitBlocking(ctx context.Context, url string, strict bool) (*jobs.Job, error)
+	// UnfinishedURLs returns the URLs of scrape jobs not finished yet
+	UnfinishedURLs(ctx context.Context) (map[string]bool, error)
+}
+
+// Deps are the services the scheduled tasks use
+type Deps struct {
+	Discoverer jobs.Discoverer
+	Fixtures   jobs.DiscoveryStore
+	Queue      Submitter
+}
+
+// Tasks builds the scheduled tasks enabled in cfg
+func Tasks(cfg config.SchedulerConfig, deps Deps) ([]Task, error) {
+	var tasks []Task
+
+	add := func(name, expr string, run func(ctx context.Context) error) error {
+		if expr == "" {
+			return nil
+		}
+		schedule, err := ParseCron(expr)
+		if err != nil {
+			return fmt.Errorf("schedule %s: %w", name, err)
+		}
+		tasks = append(tasks, Task{Name: name, Schedule: schedule, Run: run})
+		return nil
+	}
+
+	if cfg.Season != "" {
+		err := add(TaskDiscover, cfg.DiscoverCron, func(ctx context.Context) error {
+			return discover(ctx, cfg, deps)
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else if cfg.DiscoverCron != "" {
+		log.Println("⚠️ SCHEDULER_SEASON is not set, fixture discovery is not scheduled")
+	}
+
+	err := add(TaskScrapeDue, cfg.ScrapeDueCron, func(ctx context.Context) error {
+		return scrapeDue(ctx, cfg, deps)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = add(TaskRescrapeRecent, cfg.RescrapeCron, func(ctx context.Context) error {
+		return rescrapeRecent(ctx, cfg, deps)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// discover re-crawls the whole season so newly listed fixtures are picked up
+func discover(ctx context.Context, cfg config.SchedulerConfig, deps Deps) error {
+	result := jobs.Discover(ctx, deps.Discoverer, deps.Fixtures, cfg.Competition, cfg.Season, 1, scraper.SeasonGameweeks)
+	if result.Found == 0 && len(result.Errors) > 0 {
+		return fmt.Errorf("no gameweek could be crawled: %s", result.Errors[0])
+	}
+	return nil
+}
+
+// scrapeDue queues every pending fixture that kicked off at least ScrapeDelay ago
+func scrapeDue(ctx context.Context, cfg config.SchedulerConfig, deps Deps) error {
+	fixtures, err := deps.Fixtures.ListDiscoveredFixtures(ctx, domain.DiscoveryFilter{
+		Competition:   cfg.Competition,
+		Status:        domain.DiscoveryPending,
+		KickoffBefore: time.Now().Add(-cfg.ScrapeDelay),
+	})
+	if err != nil {
+		return err
+	}
+	return submit(ctx, deps.Queue, fixtures)
+}
+
+// rescrapeRecent queues fixtures kicked off within RescrapeWindow again, to
+// pick up xG revisions and retry failed scrapes
+func rescrapeRecent(ctx context.Context, cfg config.SchedulerConfig, deps Deps) error {
+	now := time.Now()
+	var fixtures []domain.DBDiscoveredFixture
+	for _, status := range []string{domain.DiscoveryScraped, domain.DiscoveryFailed} {
+		recent, err := deps.Fixtures.ListDiscoveredFixtures(ctx, domain.DiscoveryFilter{
+			Competition:   cfg.Competition,
+			Status:        status,
+			KickoffAfter:  now.Add(-cfg.RescrapeWindow),
+			KickoffBefore: now.Add(-cfg.ScrapeDelay),
+		})
+		if err != nil {
+			return err
+		}
+		fixtures = append(fixtures, recent...)
+	}
+	return submit(ctx, deps.Queue, fixtures)
+}
+
+// submit queues a scrape job for each fixture. A fixture stays pending until
+// its scrape is recorded, so fixtures whose job is still queued or running
+// from an earlier run are skipped rather than scraped twice.
+func submit(ctx context.Context, queue Submitter, fixtures []domain.DBDiscoveredFixture) error {
+	unfinished, err := queue.UnfinishedURLs(ctx)
+	if err != nil {
+		return err
+	}
+
+	queued := 0
+	for _, fixture := range fixtures {
+		if unfinished[fixture.URL] {
+			continue
+		}
+		if _, err := queue.SubmitBlocking(ctx, fixture.URL, false); err != nil {
+			return fmt.Errorf("failed to queue %s: %w", fixture.URL, err)
+		}
+		queued++
+	}
+	log.Printf("📥 Queued %d fixtures, %d already queued", queued, len(fixtures)-queued)
+	return nil
+}
@@ -0,0 +1,323 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// shotMapReady resolves once both shot map cards are on the page and the number
+// of shot markers has stopped changing for half a second
+const shotMapReady = `() => {
+	const markers = document.querySelectorAll('svg circle, svg svg[x]').length;
+	const maps = [...document.querySelectorAll('h3')].filter(h => h.textContent.includes('xG Shot Map')).length;
+	const now = Date.now();
+	if (markers !== window.__shotMarkers) {
+		window.__shotMarkers = markers;
+		window.__shotMarkersAt = now;
+		return false;
+	}
+	return maps >= 2 && markers > 0 && now - window.__shotMarkersAt >= 500;
+}`
+
+// matchLinksReady resolves once a listing page shows match links and their
+// number has stopped changing for half a second
+const matchLinksReady = `() => {
+	const links = document.querySelectorAll('a[href*="/matches/"]').length;
+	const now = Date.now();
+	if (links !== window.__matchLinks) {
+		window.__matchLinks = links;
+		window.__matchLinksAt = now;
+		return false;
+	}
+	return links > 0 && now - window.__matchLinksAt >= 500;
+}`
+
+// understatReady resolves once an Understat match page has defined its shot data
+const understatReady = `() => typeof shotsData !== 'undefined' && typeof match_info !== 'undefined'`
+
+// hideWebdriver runs before any page script so the site does not see an automated browser
+const hideWebdriver = `Object.defineProperty(navigator, 'webdriver', {get: () => undefined})`
+
+// ChromeOptions configures the headless browser
+type ChromeOptions struct {
+	Headless    bool
+	Debug       bool
+	ChromePath  string
+	MaxTabs     int
+	PageTimeout time.Duration
+}
+
+// ChromeFetcher renders pages in a long-lived Chrome process, using a bounded
+// pool of tabs. Tabs are reused between fetches and replaced when they fail.
+type ChromeFetcher struct {
+	opts ChromeOptions
+
+	// slots bounds the number of concurrently open tabs
+	slots chan struct{}
+	// idle holds tabs that finished a fetch cleanly and can be reused
+	idle chan *chromeTab
+
+	mu            sync.Mutex
+	browserCtx    context.Context
+	browserCancel context.CancelFunc
+}
+
+// chromeTab is a single browser tab and the function that closes it
+type chromeTab struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewChromeFetcher creates a new Chrome-backed fetcher. Chrome itself is
+// started lazily on the first fetch.
+func NewChromeFetcher(opts ChromeOptions) *ChromeFetcher {
+	if opts.MaxTabs < 1 {
+		opts.MaxTabs = 1
+	}
+	if opts.PageTimeout <= 0 {
+		opts.PageTimeout = 60 * time.Second
+	}
+
+	return &ChromeFetcher{
+		opts:  opts,
+		slots: make(chan struct{}, opts.MaxTabs),
+		idle:  make(chan *chromeTab, opts.MaxTabs),
+	}
+}
+
+// Fetch navigates to the URL, waits for the shot map to render and returns the page HTML.
+// Cancelling ctx stops the navigation and frees the tab.
+func (f *ChromeFetcher) Fetch(ctx context.Context, url string) (string, error) {
+	select {
+	case f.slots <- struct{}{}:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	defer func() { <-f.slots }()
+
+	tab, err := f.acquireTab()
+	if err != nil {
+		return "", err
+	}
+
+	if !f.opts.Headless {
+		log.Printf("👀 Loading in browser window: %s", url)
+	}
+
+	// chromedp needs the tab's context, so the caller's cancellation is forwarded to it
+	runCtx, cancel := context.WithTimeout(tab.ctx, f.opts.PageTimeout)
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	var pageData string
+	var matchTitle string
+
+	// A missing or refused page never renders its data, so its status is
+	// checked before waiting for it
+	resp, err := chromedp.RunResponse(runCtx, chromedp.Navigate(url))
+	if err == nil && resp != nil && resp.Status >= 400 {
+		f.releaseTab(tab)
+		return "", statusError(int(resp.Status))
+	}
+	if err == nil {
+		actions := f.waitUntilReady(url)
+		actions = append(actions,
+			chromedp.Title(&matchTitle),
+			// Extract the entire page HTML content
+			chromedp.OuterHTML(`html`, &pageData, chromedp.ByQuery),
+		)
+		err = chromedp.Run(runCtx, actions...)
+	}
+	if err != nil {
+		// The tab may be stuck mid-navigation or crashed, so it is not reused
+		f.discardTab(tab)
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("chrome navigation cancelled: %w", ctx.Err())
+		}
+		if errors.Is(err, chromedp.ErrPollingTimeout) || errors.Is(err, context.DeadlineExceeded) {
+			return "", fmt.Errorf("%w: %w", ErrTimeout, err)
+		}
+		return "", fmt.Errorf("chrome navigation failed: %w", err)
+	}
+
+	f.releaseTab(tab)
+
+	if f.opts.Debug {
+		log.Printf("🔍 Loaded page: %s", matchTitle)
+	}
+
+	return pageData, nil
+}
+
+// waitUntilReady returns the actions that wait for the page's data to render:
+// the shot maps on a match page, the shot data on an Understat match page, or
+// the match links on a listing page
+func (f *ChromeFetcher) waitUntilReady(url string) []chromedp.Action {
+	poll := func(ready string) chromedp.Action {
+		return chromedp.PollFunction(ready, nil,
+			chromedp.WithPollingInterval(100*time.Millisecond),
+			chromedp.WithPollingTimeout(f.opts.PageTimeout),
+		)
+	}
+
+	if IsUnderstatURL(url) {
+		return []chromedp.Action{poll(understatReady)}
+	}
+	if !IsShotMapURL(url) {
+		return []chromedp.Action{poll(matchLinksReady)}
+	}
+	return []chromedp.Action{
+		chromedp.WaitVisible(`//h3[contains(@class, 'text-card-title') and contains(text(), 'xG Shot Map')]`, chromedp.BySearch),
+		poll(shotMapReady),
+	}
+}
+
+// Close shuts down every tab and the browser process
+func (f *ChromeFetcher) Close() error {
+	for len(f.idle) > 0 {
+		tab := <-f.idle
+		tab.cancel()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.browserCancel != nil {
+		f.browserCancel()
+		f.browserCtx, f.browserCancel = nil, nil
+	}
+	return nil
+}
+
+// acquireTab returns an idle tab, or opens a new one when none is free
+func (f *ChromeFetcher) acquireTab() (*chromeTab, error) {
+	for {
+		select {
+		case tab := <-f.idle:
+			if tab.ctx.Err() == nil {
+				return tab, nil
+			}
+			// The tab (or the whole browser) went away while idle
+			tab.cancel()
+		default:
+			return f.openTab()
+		}
+	}
+}
+
+// releaseTab returns a healthy tab to the idle pool
+func (f *ChromeFetcher) releaseTab(tab *chromeTab) {
+	select {
+	case f.idle <- tab:
+	default:
+		tab.cancel()
+	}
+}
+
+// discardTab closes a tab that failed so the next fetch gets a fresh one
+func (f *ChromeFetcher) discardTab(tab *chromeTab) {
+	tab.cancel()
+	if f.opts.Debug {
+		log.Println("♻️ Recycled browser tab after a failed fetch")
+	}
+}
+
+// openTab opens a new tab in the shared browser, starting Chrome if needed
+func (f *ChromeFetcher) openTab() (*chromeTab, error) {
+	browserCtx, err := f.browser()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := chromedp.NewContext(browserCtx)
+	err = chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		_, err := page.AddScriptToEvaluateOnNewDocument(hideWebdriver).Do(ctx)
+		return err
+	}))
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to open browser tab: %w", err)
+	}
+
+	return &chromeTab{ctx: ctx, cancel: cancel}, nil
+}
+
+// browser returns the shared browser context, (re)starting Chrome when it is
+// not running yet or has exited
+func (f *ChromeFetcher) browser() (context.Context, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.browserCtx != nil && f.browserCtx.Err() == nil {
+		return f.browserCtx, nil
+	}
+	if f.browserCancel != nil {
+		log.Println("⚠️ Chrome exited, starting a new browser")
+		f.browserCancel()
+	}
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), f.allocatorOptions()...)
+
+	var browserCtx context.Context
+	var browserCancel context.CancelFunc
+	if f.opts.Debug {
+		browserCtx, browserCancel = chromedp.NewContext(allocCtx, chromedp.WithDebugf(log.Printf))
+	} else {
+		browserCtx, browserCancel = chromedp.NewContext(allocCtx)
+	}
+
+	// Running an empty task list starts the browser process
+	if err := chromedp.Run(browserCtx); err != nil {
+		browserCancel()
+		allocCancel()
+		return nil, fmt.Errorf("failed to start chrome: %w", err)
+	}
+
+	f.browserCtx = browserCtx
+	f.browserCancel = func() {
+		browserCancel()
+		allocCancel()
+	}
+	return f.browserCtx, nil
+}
+
+// allocatorOptions returns the Chrome flags used for every browser process
+func (f *ChromeFetcher) allocatorOptions() []chromedp.ExecAllocatorOption {
+	// Prepare Chrome options to bypass bot detection
+	opts := []chromedp.ExecAllocatorOption{
+		chromedp.NoFirstRun,
+		chromedp.NoDefaultBrowserCheck,
+		chromedp.DisableGPU,
+		chromedp.NoSandbox,                           // Required for Cloud Run
+		chromedp.Flag("disable-dev-shm-usage", true), // Overcome limited resource problems
+		chromedp.Flag("disable-setuid-sandbox", true),
+		chromedp.Flag("single-process", false),
+		chromedp.UserAgent(userAgent),
+		chromedp.Flag("disable-blink-features", "AutomationControlled"),
+		chromedp.Flag("excludeSwitches", "enable-automation"),
+		chromedp.Flag("disable-extensions", false),
+		chromedp.WindowSize(1920, 1080),
+	}
+
+	// Use system Chrome if available (Cloud Run)
+	if f.opts.ChromePath != "" {
+		opts = append(opts, chromedp.ExecPath(f.opts.ChromePath))
+		if f.opts.Debug {
+			log.Printf("🔧 Using Chrome at: %s", f.opts.ChromePath)
+		}
+	}
+
+	if f.opts.Headless {
+		opts = append(opts, chromedp.Headless)
+	}
+
+	return opts
+}
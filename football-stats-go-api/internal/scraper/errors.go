@@ -0,0 +1,144 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Classes of scrape failure. Errors returned by the service wrap one of
+// these when the cause is known, so callers can test them with errors.Is.
+var (
+	// ErrNotFound means the page does not exist
+	ErrNotFound = errors.New("page not found")
+	// ErrBlocked means the site refused the request or served a bot check
+	ErrBlocked = errors.New("blocked by the site")
+	// ErrTimeout means the page did not load in time or the site was
+	// temporarily unavailable
+	ErrTimeout = errors.New("page did not load in time")
+	// ErrParse means the page loaded but its data could not be read
+	ErrParse = errors.New("page could not be parsed")
+	// ErrIncomplete means a strict scrape was rejected because its parse
+	// report is incomplete; the report says what was missing
+	ErrIncomplete = errors.New("fixture parsed incompletely")
+	// ErrDisallowed means robots.txt asks crawlers not to fetch the page
+	ErrDisallowed = errors.New("disallowed by robots.txt")
+	// ErrUnsupportedURL means no provider handles the URL
+	ErrUnsupportedURL = errors.New("unsupported url")
+)
+
+// ErrorKind returns a short name for the class of err: not_found, blocked,
+// timeout, parse, incomplete, disallowed or unsupported. It returns "" for
+// unclassified errors.
+func ErrorKind(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrBlocked):
+		return "blocked"
+	case errors.Is(err, ErrTimeout):
+		return "timeout"
+	case errors.Is(err, ErrParse):
+		return "parse"
+	case errors.Is(err, ErrIncomplete):
+		return "incomplete"
+	case errors.Is(err, ErrDisallowed):
+		return "disallowed"
+	case errors.Is(err, ErrUnsupportedURL):
+		return "unsupported"
+	default:
+		return ""
+	}
+}
+
+// Retryable reports whether another attempt may succeed where err failed
+func Retryable(err error) bool {
+	return errors.Is(err, ErrTimeout) || errors.Is(err, ErrBlocked)
+}
+
+// RetryPolicy controls how failed fetches are retried. Only retryable
+// errors are retried, after a jittered exponential backoff.
+type RetryPolicy struct {
+	// Attempts is the total number of tries; 1 disables retries
+	Attempts int
+	// BaseDelay is the delay before the first retry, doubled for each one after
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts
+	MaxDelay time.Duration
+}
+
+// backoff returns how long to wait after the given failed attempt (from 1).
+// The delay is drawn between half and all of the exponential delay, so
+// concurrent scrapes that failed together do not retry together.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.MaxDelay
+	if shift := attempt - 1; shift < 32 {
+		if d := p.BaseDelay << shift; d > 0 && (p.MaxDelay <= 0 || d < p.MaxDelay) {
+			delay = d
+		}
+	}
+	if delay <= 0 {
+		delay = p.BaseDelay
+	}
+
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// statusError classifies an unsuccessful HTTP status
+func statusError(status int) error {
+	switch status {
+	case http.StatusNotFound, http.StatusGone:
+		return fmt.Errorf("%w: status %d", ErrNotFound, status)
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		return fmt.Errorf("%w: status %d", ErrBlocked, status)
+	case http.StatusRequestTimeout, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return fmt.Errorf("%w: status %d", ErrTimeout, status)
+	default:
+		return fmt.Errorf("unexpected status %d", status)
+	}
+}
+
+// classify wraps a fetch error in its class when it is not classified yet.
+// Errors caused by the caller cancelling ctx are returned unchanged.
+func classify(ctx context.Context, err error) error {
+	if ctx.Err() != nil || ErrorKind(err) != "" {
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return err
+}
+
+// blockMarkers are found on bot-check and access-denied pages
+var blockMarkers = []string{
+	"<title>just a moment",
+	"<title>attention required",
+	"<title>access denied",
+	"_cf_chl_opt",
+}
+
+// isBlockPage reports whether the HTML is a bot check rather than the page asked for
+func isBlockPage(pageData string) bool {
+	page := strings.ToLower(pageData)
+	for _, marker := range blockMarkers {
+		if strings.Contains(page, marker) {
+			return true
+		}
+	}
+	return false
+}
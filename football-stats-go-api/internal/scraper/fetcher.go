@@ -0,0 +1,127 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// userAgent is sent by every fetcher so the site sees a regular desktop browser
+const userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+
+// Fetcher retrieves the HTML of a match page. Implementations stop work and
+// return ctx.Err() (possibly wrapped) when ctx is cancelled.
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) (string, error)
+}
+
+// FetcherFunc adapts a function to the Fetcher interface
+type FetcherFunc func(ctx context.Context, url string) (string, error)
+
+// Fetch calls f
+func (f FetcherFunc) Fetch(ctx context.Context, url string) (string, error) {
+	return f(ctx, url)
+}
+
+// HTTPFetcher fetches pages with a plain HTTP GET, without running JavaScript
+type HTTPFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPFetcher creates a new net/http based fetcher
+func NewHTTPFetcher(timeout time.Duration) *HTTPFetcher {
+	return &HTTPFetcher{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Fetch performs a GET request and returns the response body
+func (f *HTTPFetcher) Fetch(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", statusError(resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return string(body), nil
+}
+
+// FileFetcher reads saved pages from the local filesystem.
+// A file:// URL or an existing path is read directly; any other URL is
+// looked up in dir under the name returned by FileNameForURL.
+type FileFetcher struct {
+	dir string
+}
+
+// NewFileFetcher creates a new fetcher backed by saved HTML files in dir
+func NewFileFetcher(dir string) *FileFetcher {
+	return &FileFetcher{dir: dir}
+}
+
+// Fetch returns the saved HTML for the URL
+func (f *FileFetcher) Fetch(ctx context.Context, url string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	path := f.pathFor(url)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%w: no saved page at %s", ErrNotFound, path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read saved page %s: %w", path, err)
+	}
+
+	return string(data), nil
+}
+
+// pathFor resolves the file that holds the page for url
+func (f *FileFetcher) pathFor(url string) string {
+	if path, ok := strings.CutPrefix(url, "file://"); ok {
+		return path
+	}
+	if !strings.Contains(url, "://") {
+		if _, err := os.Stat(url); err == nil {
+			return url
+		}
+	}
+	return filepath.Join(f.dir, FileNameForURL(url))
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// FileNameForURL returns the file name under which a page for url is saved
+func FileNameForURL(url string) string {
+	name := url
+	if _, rest, ok := strings.Cut(name, "://"); ok {
+		name = rest
+	}
+	name = strings.Trim(unsafeFileChars.ReplaceAllString(name, "_"), "_")
+	return name + ".html"
+}
@@ -0,0 +1,273 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"example/hello/internal/domain"
+)
+
+// xgstatBaseURL is the site root used to build listing and match URLs
+const xgstatBaseURL = "https://www.xgstat.com"
+
+// DefaultCompetition is used when a gameweek is requested without a competition
+const DefaultCompetition = "premier-league"
+
+// SeasonGameweeks is the number of gameweeks in a Premier League season
+const SeasonGameweeks = 38
+
+// matchPathPattern matches the path of a match page, with or without a sub-page
+var matchPathPattern = regexp.MustCompile(`^/competitions/([^/]+)/(\d{4}-\d{4})/matches/([^/?#]+)`)
+
+// seasonIDPattern matches a season identifier such as 2025-2026
+var seasonIDPattern = regexp.MustCompile(`^\d{4}-\d{4}$`)
+
+// XGStatMatchID derives a stable match ID from the slug of an xgstat.com match
+// URL, or returns 0 for any other URL. xgstat.com shows no match IDs and the
+// number ending a slug such as "arsenal-manchester-united-2026-01-24" is only
+// the day of the month, so the slug is hashed; the ID stays within
+// JavaScript's safe integer range.
+func XGStatMatchID(rawURL string) int {
+	slug := xgstatMatchSlug(rawURL)
+	if slug == "" {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(slug))
+	return int(h.Sum64() & (1<<53 - 1))
+}
+
+// xgstatMatchSlug returns the match slug of an xgstat.com match URL, e.g.
+// "arsenal-manchester-united-2026-01-24", or "" for any other URL
+func xgstatMatchSlug(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	m := matchPathPattern.FindStringSubmatch(u.Path)
+	if m == nil {
+		return ""
+	}
+	return m[3]
+}
+
+// IsShotMapURL reports whether url is a match's shot map page rather than a listing
+func IsShotMapURL(url string) bool {
+	return strings.Contains(url, "/shot-maps")
+}
+
+// GameweekListingURL returns the xgstat.com page listing the matches of a gameweek
+func GameweekListingURL(competition, season string, gameweek int) string {
+	return fmt.Sprintf("%s/competitions/%s/%s/matches?gameweek=%d", xgstatBaseURL, competition, season, gameweek)
+}
+
+// ShotMapURL returns the shot map page of a match
+func ShotMapURL(competition, season, slug string) string {
+	return fmt.Sprintf("%s/competitions/%s/%s/matches/%s/advanced-analysis/shot-maps", xgstatBaseURL, competition, season, slug)
+}
+
+// slugDatePattern matches the match date at the end of a match slug
+var slugDatePattern = regexp.MustCompile(`(\d{4}-\d{2}-\d{2})$`)
+
+// matchLink is a match found on a listing page
+type matchLink struct {
+	url     string
+	kickoff *time.Time
+}
+
+// ParseMatchURLs returns the shot map URLs of every match linked from a listing
+// page, in page order and without duplicates. Only matches of the given
+// competition and season are returned.
+func ParseMatchURLs(pageData, competition, season string) ([]string, error) {
+	links, err := parseMatchLinks(pageData, competition, season)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, len(links))
+	for i, link := range links {
+		urls[i] = link.url
+	}
+	return urls, nil
+}
+
+// parseMatchLinks finds the match links of a listing page together with
+// their kick-off times
+func parseMatchLinks(pageData, competition, season string) ([]matchLink, error) {
+	doc, err := html.Parse(strings.NewReader(pageData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	var links []matchLink
+	seen := make(map[string]bool)
+	for _, link := range findAll(doc, func(n *html.Node) bool { return isElement(n, "a") && hasAttr(n, "href") }) {
+		href, err := url.Parse(attr(link, "href"))
+		if err != nil {
+			continue
+		}
+
+		m := matchPathPattern.FindStringSubmatch(href.Path)
+		if m == nil || m[1] != competition || m[2] != season {
+			continue
+		}
+
+		shotMapURL := ShotMapURL(m[1], m[2], m[3])
+		if !seen[shotMapURL] {
+			seen[shotMapURL] = true
+			links = append(links, matchLink{url: shotMapURL, kickoff: listedKickoff(link, m[3])})
+		}
+	}
+
+	return links, nil
+}
+
+// listedKickoff returns the kick-off shown next to a match link in a
+// <time datetime> element. When the listing only gives the date (in the
+// slug), the end of that day in London is used as the latest possible
+// kick-off, so the match is never treated as played too early.
+func listedKickoff(link *html.Node, slug string) *time.Time {
+	card := link
+	for i := 0; i < 3 && card.Parent != nil; i++ {
+		card = card.Parent
+		// Stop before reaching a container shared with other matches
+		if findFirst(card, func(n *html.Node) bool { return isMatchLink(n) && matchSlug(n) != slug }) != nil {
+			break
+		}
+		timeNode := findFirst(card, func(n *html.Node) bool { return isElement(n, "time") && hasAttr(n, "datetime") })
+		if timeNode == nil {
+			continue
+		}
+		if kickoff, err := time.Parse(time.RFC3339, attr(timeNode, "datetime")); err == nil {
+			kickoff = kickoff.UTC()
+			return &kickoff
+		}
+	}
+
+	m := slugDatePattern.FindStringSubmatch(slug)
+	if m == nil {
+		return nil
+	}
+	location, err := time.LoadLocation(xgstatTimezone)
+	if err != nil {
+		return nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", m[1], location)
+	if err != nil {
+		return nil
+	}
+	kickoff := day.AddDate(0, 0, 1).UTC()
+	return &kickoff
+}
+
+func isMatchLink(n *html.Node) bool {
+	return isElement(n, "a") && matchSlug(n) != ""
+}
+
+// matchSlug returns the match slug a link points to, or "" for other links
+func matchSlug(n *html.Node) string {
+	href, err := url.Parse(attr(n, "href"))
+	if err != nil {
+		return ""
+	}
+	if m := matchPathPattern.FindStringSubmatch(href.Path); m != nil {
+		return m[3]
+	}
+	return ""
+}
+
+// GameweekMatchURLs fetches a gameweek listing and returns the shot map URL of
+// each match in it
+func (s *Service) GameweekMatchURLs(ctx context.Context, competition, season string, gameweek int) ([]string, error) {
+	links, err := s.gameweekMatches(ctx, competition, season, gameweek)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, len(links))
+	for i, link := range links {
+		urls[i] = link.url
+	}
+	return urls, nil
+}
+
+// CheckGameweek reports an error for a season or gameweek that has no listing
+// page, without fetching anything
+func CheckGameweek(season string, gameweek int) error {
+	if !seasonIDPattern.MatchString(season) {
+		return fmt.Errorf("invalid season %q, expected e.g. 2025-2026", season)
+	}
+	if gameweek < 1 {
+		return fmt.Errorf("invalid gameweek %d", gameweek)
+	}
+	return nil
+}
+
+// gameweekMatches fetches a gameweek listing and returns its match links
+func (s *Service) gameweekMatches(ctx context.Context, competition, season string, gameweek int) ([]matchLink, error) {
+	if competition == "" {
+		competition = DefaultCompetition
+	}
+	if err := CheckGameweek(season, gameweek); err != nil {
+		return nil, err
+	}
+
+	listingURL := GameweekListingURL(competition, season, gameweek)
+	if s.debug {
+		log.Printf("🌐 Loading gameweek listing: %s", listingURL)
+	}
+
+	pageData, err := s.fetch(ctx, listingURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gameweek listing: %w", err)
+	}
+
+	links, err := parseMatchLinks(pageData, competition, season)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrParse, err)
+	}
+	if len(links) == 0 {
+		return nil, fmt.Errorf("%w: no matches found for %s %s gameweek %d", ErrNotFound, competition, season, gameweek)
+	}
+
+	return links, nil
+}
+
+// DiscoverGameweek lists the matches of a gameweek as pending discovered
+// fixtures. The fixture ID is derived from the URL's slug, which stays the
+// same once the match has been played and scraped.
+func (s *Service) DiscoverGameweek(ctx context.Context, competition, season string, gameweek int) ([]domain.DBDiscoveredFixture, error) {
+	if competition == "" {
+		competition = DefaultCompetition
+	}
+
+	links, err := s.gameweekMatches(ctx, competition, season, gameweek)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	fixtures := make([]domain.DBDiscoveredFixture, 0, len(links))
+	for _, link := range links {
+		fixtures = append(fixtures, domain.DBDiscoveredFixture{
+			URL:          link.url,
+			FixtureID:    XGStatMatchID(link.url),
+			Competition:  competition,
+			Season:       season,
+			Gameweek:     gameweek,
+			Kickoff:      link.kickoff,
+			Status:       domain.DiscoveryPending,
+			DiscoveredAt: now,
+		})
+	}
+
+	return fixtures, nil
+}
@@ -0,0 +1,290 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// robotsTTL is how long a host's robots.txt is trusted before it is fetched again
+const robotsTTL = 12 * time.Hour
+
+// PolitenessOptions configures how hard the scraper may hit each host
+type PolitenessOptions struct {
+	// RequestsPerMinute is the sustained request rate per host; 0 disables it
+	RequestsPerMinute float64
+	// Burst is how many requests may be made back to back before the rate applies
+	Burst int
+	// MinInterval is the smallest gap between two requests to a host. A
+	// longer robots.txt Crawl-delay takes precedence.
+	MinInterval time.Duration
+	// RespectRobots makes the fetcher read robots.txt and skip disallowed pages
+	RespectRobots bool
+	// RobotsAgent is the user-agent name looked up in robots.txt groups
+	RobotsAgent string
+}
+
+// HostState is a snapshot of the limiter for one host
+type HostState struct {
+	Host string `json:"host"`
+	// Requests is the number of pages fetched from the host so far
+	Requests int64 `json:"requests"`
+	// Waiting is the number of fetches waiting for their turn
+	Waiting int `json:"waiting"`
+	// Tokens is the burst capacity left
+	Tokens float64 `json:"tokens"`
+	// Interval is the enforced gap between two requests
+	Interval string `json:"interval"`
+	// NextRequestAt is the earliest time the next request may start
+	NextRequestAt time.Time `json:"next_request_at"`
+	// RobotsCheckedAt is when robots.txt was last read, if it was
+	RobotsCheckedAt *time.Time `json:"robots_checked_at,omitempty"`
+	CrawlDelay      string     `json:"crawl_delay,omitempty"`
+}
+
+// Politeness enforces per-host rate limits and robots.txt rules, so batch
+// scrapes do not get our address blocked
+type Politeness struct {
+	opts   PolitenessOptions
+	client *http.Client
+
+	mu    sync.Mutex
+	hosts map[string]*hostLimiter
+}
+
+// hostLimiter is the rate limiting and robots.txt state of one host
+type hostLimiter struct {
+	mu       sync.Mutex
+	tokens   float64
+	updated  time.Time
+	next     time.Time
+	requests int64
+	waiting  int
+
+	robots          *robotsRules
+	robotsCheckedAt time.Time
+	// robotsFetch serialises robots.txt fetches so a host's file is read once
+	robotsFetch sync.Mutex
+}
+
+// NewPoliteness creates the limiter for opts
+func NewPoliteness(opts PolitenessOptions) *Politeness {
+	if opts.Burst < 1 {
+		opts.Burst = 1
+	}
+	return &Politeness{
+		opts:   opts,
+		client: &http.Client{Timeout: 10 * time.Second},
+		hosts:  make(map[string]*hostLimiter),
+	}
+}
+
+// Wait checks url against robots.txt and blocks until its host may be sent
+// another request. URLs without a host, such as saved files, pass straight away.
+func (f *Politeness) Wait(ctx context.Context, url string) error {
+	u, err := neturl.Parse(url)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	host := f.host(u.Host)
+
+	if f.opts.RespectRobots {
+		rules, err := f.robots(ctx, host, u)
+		if err != nil {
+			return err
+		}
+		if !rules.allowed(u.RequestURI()) {
+			return fmt.Errorf("%w: %s", ErrDisallowed, u.RequestURI())
+		}
+	}
+
+	return f.wait(ctx, host)
+}
+
+// State returns a snapshot of every host seen so far, ordered by host
+func (f *Politeness) State() []HostState {
+	f.mu.Lock()
+	names := make([]string, 0, len(f.hosts))
+	for name := range f.hosts {
+		names = append(names, name)
+	}
+	f.mu.Unlock()
+	sort.Strings(names)
+
+	states := make([]HostState, 0, len(names))
+	for _, name := range names {
+		host := f.host(name)
+
+		host.mu.Lock()
+		robots, checkedAt := host.robots, host.robotsCheckedAt
+		state := HostState{
+			Host:          name,
+			Requests:      host.requests,
+			Waiting:       host.waiting,
+			Tokens:        f.refill(host, time.Now()),
+			Interval:      f.interval(robots).String(),
+			NextRequestAt: host.next,
+		}
+		host.mu.Unlock()
+
+		if !checkedAt.IsZero() {
+			state.RobotsCheckedAt = &checkedAt
+		}
+		if robots != nil && robots.crawlDelay > 0 {
+			state.CrawlDelay = robots.crawlDelay.String()
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
+// host returns the limiter of a host, creating it on first use
+func (f *Politeness) host(name string) *hostLimiter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	host, ok := f.hosts[name]
+	if !ok {
+		host = &hostLimiter{tokens: float64(f.opts.Burst), updated: time.Now()}
+		f.hosts[name] = host
+	}
+	return host
+}
+
+// wait blocks until the host may be sent another request. The slot is only
+// taken once the wait is over, so a fetch cancelled while waiting leaves the
+// host's schedule as it was.
+func (f *Politeness) wait(ctx context.Context, host *hostLimiter) error {
+	host.mu.Lock()
+	host.waiting++
+	host.mu.Unlock()
+
+	defer func() {
+		host.mu.Lock()
+		host.waiting--
+		host.mu.Unlock()
+	}()
+
+	for {
+		host.mu.Lock()
+		now := time.Now()
+		at := f.nextSlot(host, now)
+		if !at.After(now) {
+			if f.opts.RequestsPerMinute > 0 {
+				host.tokens--
+			}
+			host.next = now.Add(f.interval(host.robots))
+			host.requests++
+			host.mu.Unlock()
+			return nil
+		}
+		host.mu.Unlock()
+
+		// Another fetch may take the slot first, so it is checked again
+		timer := time.NewTimer(time.Until(at))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// nextSlot returns the earliest time the host may be sent a request, given
+// its tokens and the interval since its last request. host.mu must be held.
+func (f *Politeness) nextSlot(host *hostLimiter, now time.Time) time.Time {
+	at := now
+	if tokens := f.refill(host, now); f.opts.RequestsPerMinute > 0 && tokens < 1 {
+		at = now.Add(time.Duration((1 - tokens) / f.opts.RequestsPerMinute * float64(time.Minute)))
+	}
+	if at.Before(host.next) {
+		at = host.next
+	}
+	return at
+}
+
+// refill returns the host's tokens topped up for the time since the last
+// update. host.mu must be held.
+func (f *Politeness) refill(host *hostLimiter, now time.Time) float64 {
+	if f.opts.RequestsPerMinute <= 0 {
+		return float64(f.opts.Burst)
+	}
+	elapsed := now.Sub(host.updated).Minutes()
+	host.tokens = min(float64(f.opts.Burst), host.tokens+elapsed*f.opts.RequestsPerMinute)
+	host.updated = now
+	return host.tokens
+}
+
+// interval is the gap enforced between two requests to a host
+func (f *Politeness) interval(robots *robotsRules) time.Duration {
+	if robots != nil && f.opts.RespectRobots {
+		return max(f.opts.MinInterval, robots.crawlDelay)
+	}
+	return f.opts.MinInterval
+}
+
+// robots returns the host's robots.txt rules, fetching them when they are
+// missing or older than robotsTTL. A robots.txt that does not exist allows
+// everything; one that cannot be loaded is a retryable failure, as crawling
+// without knowing the rules is not allowed.
+func (f *Politeness) robots(ctx context.Context, host *hostLimiter, u *neturl.URL) (*robotsRules, error) {
+	host.robotsFetch.Lock()
+	defer host.robotsFetch.Unlock()
+
+	host.mu.Lock()
+	cached, checkedAt := host.robots, host.robotsCheckedAt
+	host.mu.Unlock()
+	if cached != nil && time.Since(checkedAt) < robotsTTL {
+		return cached, nil
+	}
+
+	robotsURL := u.Scheme + "://" + u.Host + "/robots.txt"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build robots.txt request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		if cached != nil {
+			return cached, nil
+		}
+		return nil, fmt.Errorf("%w: failed to load %s: %w", ErrTimeout, robotsURL, err)
+	}
+	defer resp.Body.Close()
+
+	var rules *robotsRules
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 512*1024))
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read %s: %w", ErrTimeout, robotsURL, err)
+		}
+		rules = parseRobots(string(body), f.opts.RobotsAgent)
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		rules = &robotsRules{}
+	default:
+		if cached != nil {
+			return cached, nil
+		}
+		return nil, fmt.Errorf("%w: %s returned status %d", ErrTimeout, robotsURL, resp.StatusCode)
+	}
+
+	if rules.crawlDelay > 0 && cached == nil {
+		log.Printf("🤖 %s asks for a crawl delay of %s", u.Host, rules.crawlDelay)
+	}
+
+	host.mu.Lock()
+	host.robots = rules
+	host.robotsCheckedAt = time.Now()
+	host.mu.Unlock()
+	return rules, nil
+}
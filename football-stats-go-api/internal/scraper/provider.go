@@ -0,0 +1,134 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strings"
+
+	"example/hello/internal/domain"
+)
+
+// Provider is a site match pages are scraped from. A new source is added by
+// implementing Provider and registering it; the service and API route URLs to
+// it through the Registry.
+type Provider interface {
+	// Name is the source recorded on the provider's fixtures
+	Name() string
+	// MatchURL reports whether url is one of the provider's match pages
+	MatchURL(url string) bool
+	// Fetch loads a match page. fetcher is the service's, which already
+	// rate limits, retries and archives every request.
+	Fetch(ctx context.Context, fetcher Fetcher, url string) (string, error)
+	// Parse converts a fetched page. The report is returned whenever the
+	// page could be read, even when the fixture fails validation; callers go
+	// through ParseWith, which fills in a report a provider leaves out.
+	Parse(pageData, url string) (*domain.DBXGStatFixture, *ParseReport, error)
+}
+
+// Registry routes match URLs to the provider that handles them
+type Registry struct {
+	providers []Provider
+}
+
+// NewRegistry creates a registry of the given providers. The first provider
+// whose MatchURL accepts a URL handles it.
+func NewRegistry(providers ...Provider) *Registry {
+	return &Registry{providers: providers}
+}
+
+// DefaultRegistry returns a registry of every built-in provider
+func DefaultRegistry() *Registry {
+	return NewRegistry(XGStatProvider{}, UnderstatProvider{})
+}
+
+// Register adds a provider after the existing ones
+func (r *Registry) Register(provider Provider) {
+	r.providers = append(r.providers, provider)
+}
+
+// Lookup returns the provider for url, or an error wrapping ErrUnsupportedURL
+func (r *Registry) Lookup(url string) (Provider, error) {
+	for _, provider := range r.providers {
+		if provider.MatchURL(url) {
+			return provider, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s is not a match page of %s", ErrUnsupportedURL, url, strings.Join(r.Names(), ", "))
+}
+
+// Names lists the registered providers
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.providers))
+	for i, provider := range r.providers {
+		names[i] = provider.Name()
+	}
+	return names
+}
+
+// ParsePage parses a match page with the built-in provider for its URL
+func ParsePage(pageData, url string) (*domain.DBXGStatFixture, *ParseReport, error) {
+	provider, err := DefaultRegistry().Lookup(url)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ParseWith(provider, pageData, url)
+}
+
+// ParseWith parses a page with provider. A successful parse always comes with
+// a fixture and a report: a missing fixture is an error, and a missing report
+// is replaced by an incomplete one saying so, which strict scrapes reject.
+func ParseWith(provider Provider, pageData, url string) (*domain.DBXGStatFixture, *ParseReport, error) {
+	fixture, report, err := provider.Parse(pageData, url)
+	if err == nil && fixture == nil {
+		err = fmt.Errorf("%s returned no fixture", provider.Name())
+	}
+	if report == nil && err == nil {
+		report = newParseReport(url)
+		report.expected = nil
+		report.issue("%s returned no parse report", provider.Name())
+		report.finish()
+	}
+	return fixture, report, err
+}
+
+// XGStatProvider scrapes the shot map pages of xgstat.com
+type XGStatProvider struct{}
+
+func (XGStatProvider) Name() string { return domain.SourceXGStat }
+
+func (XGStatProvider) MatchURL(url string) bool {
+	return hostIs(url, "xgstat.com") && IsShotMapURL(url)
+}
+
+func (XGStatProvider) Fetch(ctx context.Context, fetcher Fetcher, url string) (string, error) {
+	return fetcher.Fetch(ctx, url)
+}
+
+func (XGStatProvider) Parse(pageData, url string) (*domain.DBXGStatFixture, *ParseReport, error) {
+	return ParseXGStatReport(pageData, url)
+}
+
+// UnderstatProvider scrapes the match pages of understat.com
+type UnderstatProvider struct{}
+
+func (UnderstatProvider) Name() string { return domain.SourceUnderstat }
+
+func (UnderstatProvider) MatchURL(url string) bool { return IsUnderstatURL(url) }
+
+func (UnderstatProvider) Fetch(ctx context.Context, fetcher Fetcher, url string) (string, error) {
+	return fetcher.Fetch(ctx, url)
+}
+
+func (UnderstatProvider) Parse(pageData, url string) (*domain.DBXGStatFixture, *ParseReport, error) {
+	return ParseUnderstatReport(pageData, url)
+}
+
+// hostIs reports whether url is on host, with or without www
+func hostIs(url, host string) bool {
+	u, err := neturl.Parse(url)
+	if err != nil {
+		return false
+	}
+	return strings.TrimPrefix(u.Hostname(), "www.") == host
+}
@@ -0,0 +1,197 @@
+package scraper
+
+import (
+	"fmt"
+	"math"
+
+	"example/hello/internal/domain"
+)
+
+// Fields tracked by ParseReport, in reporting order
+const (
+	fieldID          = "id"
+	fieldTeams       = "teams"
+	fieldScore       = "score"
+	fieldXG          = "xg"
+	fieldGameweek    = "gameweek"
+	fieldDate        = "date"
+	fieldHomeShotMap = "home_shot_map"
+	fieldAwayShotMap = "away_shot_map"
+)
+
+var reportFields = []string{
+	fieldID, fieldTeams, fieldScore, fieldXG, fieldGameweek, fieldDate, fieldHomeShotMap, fieldAwayShotMap,
+}
+
+// xgTolerance is the largest accepted gap between the headline xG and the
+// summed shot xG or player table xG, which are rounded to two decimals on the page
+const xgTolerance = 0.05
+
+// ParseReport describes how completely a page was parsed
+type ParseReport struct {
+	URL           string     `json:"url"`
+	FieldsFound   []string   `json:"fields_found"`
+	FieldsMissing []string   `json:"fields_missing"`
+	Home          *TeamCheck `json:"home,omitempty"`
+	Away          *TeamCheck `json:"away,omitempty"`
+	Issues        []string   `json:"issues"`
+	// Gaps lists data the page itself does not provide, e.g. per-shot xG on
+	// xgstat.com. Unlike Issues they do not make the report incomplete.
+	Gaps []string `json:"gaps"`
+	// ShotDetail is set when every shot names its player and has a minute
+	// and an xG. xgstat.com shot markers carry none of the three, so its
+	// shots are stored with an empty player, minute 0 and xG 0.
+	ShotDetail bool `json:"shot_detail"`
+	Complete   bool `json:"complete"`
+
+	// expected lists the fields the page's source provides
+	expected []string
+	seen     map[string]bool
+}
+
+// TeamCheck compares one team's shot markers with the page's own totals
+type TeamCheck struct {
+	Shots             int     `json:"shots"`
+	TableShots        int     `json:"table_shots"` // -1 when the player table has no shots column
+	Goals             int     `json:"goals"`
+	TableGoals        int     `json:"table_goals"`
+	ShotXG            float64 `json:"shot_xg"`
+	HeadlineXG        float64 `json:"headline_xg"`
+	TableXG           float64 `json:"table_xg"`
+	ShotsWithXG       int     `json:"shots_with_xg"`
+	UnattributedShots int     `json:"unattributed_shots"` // shots without a player
+
+	// detailed counts shots with a player, a minute and an xG
+	detailed int
+	// hasTable is set when the page has a player table to compare against
+	hasTable bool
+}
+
+func newParseReport(url string) *ParseReport {
+	return &ParseReport{
+		URL:           url,
+		FieldsFound:   []string{},
+		FieldsMissing: []string{},
+		Issues:        []string{},
+		Gaps:          []string{},
+		expected:      reportFields,
+		seen:          make(map[string]bool),
+	}
+}
+
+// found records whether a field was read from the page
+func (r *ParseReport) found(field string, ok bool) {
+	if ok {
+		r.seen[field] = true
+	}
+}
+
+// issue records a consistency problem
+func (r *ParseReport) issue(format string, args ...interface{}) {
+	r.Issues = append(r.Issues, fmt.Sprintf(format, args...))
+}
+
+// gap records data the page does not provide
+func (r *ParseReport) gap(format string, args ...interface{}) {
+	r.Gaps = append(r.Gaps, fmt.Sprintf(format, args...))
+}
+
+// finish fills the found/missing lists, checks each team and sets Complete
+func (r *ParseReport) finish() {
+	for _, field := range r.expected {
+		if r.seen[field] {
+			r.FieldsFound = append(r.FieldsFound, field)
+		} else {
+			r.FieldsMissing = append(r.FieldsMissing, field)
+		}
+	}
+
+	r.Home.check(r, "home")
+	r.Away.check(r, "away")
+
+	r.ShotDetail = (r.Home != nil || r.Away != nil) && r.Home.hasShotDetail() && r.Away.hasShotDetail()
+	r.Complete = len(r.FieldsMissing) == 0 && len(r.Issues) == 0
+}
+
+func newTeamCheck(headlineXG float64, shots []domain.DBXGStatShot, players []playerRow) *TeamCheck {
+	check := &TeamCheck{
+		Shots:      len(shots),
+		HeadlineXG: headlineXG,
+		hasTable:   len(players) > 0,
+	}
+
+	for _, shot := range shots {
+		check.ShotXG += shot.XG
+		if shot.IsGoal {
+			check.Goals++
+		}
+		if shot.XG > 0 {
+			check.ShotsWithXG++
+		}
+		if shot.PlayerName == "" {
+			check.UnattributedShots++
+		}
+		if shot.PlayerName != "" && shot.Minute > 0 && shot.XG > 0 {
+			check.detailed++
+		}
+	}
+
+	for _, player := range players {
+		check.TableXG += player.XG
+		check.TableGoals += player.Goals
+		if player.Shots < 0 || check.TableShots < 0 {
+			check.TableShots = -1
+		} else {
+			check.TableShots += player.Shots
+		}
+	}
+	if len(players) == 0 {
+		check.TableShots = -1
+	}
+
+	check.ShotXG = roundXG(check.ShotXG)
+	check.TableXG = roundXG(check.TableXG)
+	return check
+}
+
+// check adds an issue to the report for every total that does not line up
+func (c *TeamCheck) check(r *ParseReport, side string) {
+	if c == nil {
+		return
+	}
+
+	if c.TableShots >= 0 && c.Shots != c.TableShots {
+		r.issue("%s: %d shot markers but the player table lists %d shots", side, c.Shots, c.TableShots)
+	}
+	if c.Goals != c.TableGoals {
+		r.issue("%s: %d goal markers but the player table lists %d goals", side, c.Goals, c.TableGoals)
+	}
+	if c.hasTable && math.Abs(c.TableXG-c.HeadlineXG) > xgTolerance {
+		r.issue("%s: player table xG sums to %.2f but the headline xG is %.2f", side, c.TableXG, c.HeadlineXG)
+	}
+
+	// Shot xG can only be summed when every marker carries it; xgstat.com
+	// markers carry none, which is a gap in the source rather than a parse error
+	switch {
+	case c.Shots > 0 && c.ShotsWithXG == 0:
+		r.gap("%s: shot markers carry no xG, so per-shot xG is 0", side)
+	case c.ShotsWithXG < c.Shots:
+		r.issue("%s: only %d of %d shots have xG", side, c.ShotsWithXG, c.Shots)
+	case math.Abs(c.ShotXG-c.HeadlineXG) > xgTolerance:
+		r.issue("%s: shot xG sums to %.2f but the headline xG is %.2f", side, c.ShotXG, c.HeadlineXG)
+	}
+	if c.UnattributedShots > 0 {
+		r.gap("%s: %d of %d shots could not be attributed to a player", side, c.UnattributedShots, c.Shots)
+	}
+}
+
+// hasShotDetail reports whether every shot has a player, minute and xG. A
+// missing team has nothing to lack.
+func (c *TeamCheck) hasShotDetail() bool {
+	return c == nil || c.detailed == c.Shots
+}
+
+// roundXG trims floating point noise from summed xG values
+func roundXG(xg float64) float64 {
+	return math.Round(xg*1000) / 1000
+}
@@ -0,0 +1,116 @@
+package scraper
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// robotsRules are the robots.txt rules that apply to our user agent
+type robotsRules struct {
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+// robotsRule is one Allow or Disallow line
+type robotsRule struct {
+	pattern string
+	match   *regexp.Regexp
+	allow   bool
+}
+
+// parseRobots reads a robots.txt file and keeps the group for agent, or the
+// * group when no group names agent
+func parseRobots(body, agent string) *robotsRules {
+	agent = strings.ToLower(agent)
+
+	var named, wildcard robotsRules
+	var foundNamed bool
+	// Groups the current lines apply to; consecutive user-agent lines share a group
+	var current []*robotsRules
+	inAgents := false
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if key == "user-agent" {
+			if !inAgents {
+				current = nil
+			}
+			inAgents = true
+			switch name := strings.ToLower(value); {
+			case name == "*":
+				current = append(current, &wildcard)
+			case agent != "" && strings.Contains(agent, name):
+				current = append(current, &named)
+				foundNamed = true
+			}
+			continue
+		}
+		inAgents = false
+
+		for _, group := range current {
+			switch key {
+			case "allow", "disallow":
+				// An empty Disallow allows everything and adds no rule
+				if value != "" {
+					group.rules = append(group.rules, robotsRule{
+						pattern: value,
+						match:   compileRobotsPattern(value),
+						allow:   key == "allow",
+					})
+				}
+			case "crawl-delay":
+				if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+					group.crawlDelay = time.Duration(seconds * float64(time.Second))
+				}
+			}
+		}
+	}
+
+	if foundNamed {
+		return &named
+	}
+	return &wildcard
+}
+
+// allowed reports whether path (with its query) may be fetched. The longest
+// matching rule wins, and Allow wins a tie.
+func (r *robotsRules) allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+
+	best, allow := -1, true
+	for _, rule := range r.rules {
+		if !rule.match.MatchString(path) {
+			continue
+		}
+		if n := len(rule.pattern); n > best || (n == best && rule.allow) {
+			best, allow = n, rule.allow
+		}
+	}
+	return allow
+}
+
+// compileRobotsPattern turns a robots.txt path pattern into a regexp, where *
+// matches any characters and a trailing $ anchors the end of the path
+func compileRobotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
@@ -0,0 +1,276 @@
+package scraper
+
+import (
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"example/hello/internal/coordinates"
+	"example/hello/internal/domain"
+)
+
+// understatDateLayout is the format of match_info's date, which is in UTC
+const understatDateLayout = "2006-01-02 15:04:05"
+
+// understatFields are the report fields an Understat match page provides; it
+// has no gameweek
+var understatFields = []string{
+	fieldID, fieldTeams, fieldScore, fieldXG, fieldDate, fieldHomeShotMap, fieldAwayShotMap,
+}
+
+// understatSituations maps Understat's situation names to the domain ones
+var understatSituations = map[string]string{
+	"OpenPlay":       domain.SituationOpenPlay,
+	"FromCorner":     domain.SituationCorner,
+	"SetPiece":       domain.SituationSetPiece,
+	"DirectFreekick": domain.SituationDirectFreeKick,
+	"Penalty":        domain.SituationPenalty,
+}
+
+// understatOwnGoal is the result of an own goal, which is not a shot
+const understatOwnGoal = "OwnGoal"
+
+// maxShotMinute is the latest minute the shots table accepts. Stoppage time
+// after extra time can take a cup match past it.
+const maxShotMinute = 120
+
+// understatShot is one entry of the page's shotsData. Understat sends every
+// value as a string.
+type understatShot struct {
+	Minute    string `json:"minute"`
+	Result    string `json:"result"`
+	X         string `json:"X"`
+	Y         string `json:"Y"`
+	XG        string `json:"xG"`
+	Player    string `json:"player"`
+	Situation string `json:"situation"`
+}
+
+// understatMatchInfo is the part of the page's match_info used for the fixture
+type understatMatchInfo struct {
+	HomeTeam  string `json:"team_h"`
+	AwayTeam  string `json:"team_a"`
+	HomeGoals string `json:"h_goals"`
+	AwayGoals string `json:"a_goals"`
+	HomeXG    string `json:"h_xg"`
+	AwayXG    string `json:"a_xg"`
+	Date      string `json:"date"`
+}
+
+// IsUnderstatURL reports whether url is an understat.com match page
+func IsUnderstatURL(url string) bool {
+	u, err := neturl.Parse(url)
+	if err != nil {
+		return false
+	}
+	return hostIs(url, "understat.com") && strings.HasPrefix(u.Path, "/match/")
+}
+
+// ParseUnderstatReport parses an understat.com match page from the JSON blobs
+// its scripts embed: shotsData holds every shot and match_info the teams,
+// score and xG. Like ParseXGStatReport, the report is returned even when the
+// fixture fails validation.
+func ParseUnderstatReport(pageData, url string) (*domain.DBXGStatFixture, *ParseReport, error) {
+	fixture := &domain.DBXGStatFixture{
+		Source:    domain.SourceUnderstat,
+		HomeShots: []domain.DBXGStatShot{},
+		AwayShots: []domain.DBXGStatShot{},
+	}
+	report := newParseReport(url)
+	report.expected = understatFields
+
+	fixture.ID = extractIDFromURL(url)
+	report.found(fieldID, fixture.ID != 0)
+
+	var info understatMatchInfo
+	if err := embeddedJSON(pageData, "match_info", &info); err != nil {
+		report.issue("%v", err)
+	} else {
+		fixture.HomeTeam, fixture.AwayTeam = info.HomeTeam, info.AwayTeam
+		report.found(fieldTeams, fixture.HomeTeam != "" && fixture.AwayTeam != "")
+
+		homeGoals, errHome := strconv.Atoi(info.HomeGoals)
+		awayGoals, errAway := strconv.Atoi(info.AwayGoals)
+		fixture.HomeScore, fixture.AwayScore = homeGoals, awayGoals
+		report.found(fieldScore, errHome == nil && errAway == nil)
+
+		homeXG, errHome := strconv.ParseFloat(info.HomeXG, 64)
+		awayXG, errAway := strconv.ParseFloat(info.AwayXG, 64)
+		fixture.HomeXG, fixture.AwayXG = roundXG(homeXG), roundXG(awayXG)
+		report.found(fieldXG, errHome == nil && errAway == nil)
+
+		if date, err := time.Parse(understatDateLayout, info.Date); err != nil {
+			report.issue("invalid match date %q", info.Date)
+		} else {
+			fixture.Date = date
+			report.found(fieldDate, true)
+		}
+	}
+
+	var shots map[string][]understatShot
+	if err := embeddedJSON(pageData, "shotsData", &shots); err != nil {
+		report.issue("%v", err)
+	} else {
+		// An own goal is listed with the shots of the scorer's team and counts
+		// for the other side
+		homeOwnGoals, awayOwnGoals := 0, 0
+		for _, side := range []string{"h", "a"} {
+			list, ok := shots[side]
+			if !ok {
+				continue
+			}
+			for _, raw := range list {
+				if raw.Result == understatOwnGoal {
+					if side == "h" {
+						awayOwnGoals++
+					} else {
+						homeOwnGoals++
+					}
+					continue
+				}
+				shot, err := understatToShot(raw)
+				if err != nil {
+					report.issue("%s: %v", understatSideName(side), err)
+					continue
+				}
+				if shot.Minute > maxShotMinute {
+					report.issue("%s: shot by %s in minute %d is stored as minute %d",
+						understatSideName(side), shot.PlayerName, shot.Minute, maxShotMinute)
+					shot.Minute = maxShotMinute
+				}
+				if side == "h" {
+					fixture.HomeShots = append(fixture.HomeShots, shot)
+				} else {
+					fixture.AwayShots = append(fixture.AwayShots, shot)
+				}
+			}
+		}
+
+		if _, ok := shots["h"]; ok {
+			report.found(fieldHomeShotMap, true)
+			report.Home = newTeamCheck(fixture.HomeXG, fixture.HomeShots, nil)
+			report.Home.TableGoals = fixture.HomeScore - homeOwnGoals
+		}
+		if _, ok := shots["a"]; ok {
+			report.found(fieldAwayShotMap, true)
+			report.Away = newTeamCheck(fixture.AwayXG, fixture.AwayShots, nil)
+			report.Away.TableGoals = fixture.AwayScore - awayOwnGoals
+		}
+	}
+
+	report.finish()
+
+	if err := validateFixture(fixture); err != nil {
+		return nil, report, err
+	}
+
+	return fixture, report, nil
+}
+
+// understatToShot converts one shotsData entry
+func understatToShot(raw understatShot) (domain.DBXGStatShot, error) {
+	x, errX := strconv.ParseFloat(raw.X, 64)
+	y, errY := strconv.ParseFloat(raw.Y, 64)
+	if errX != nil || errY != nil {
+		return domain.DBXGStatShot{}, fmt.Errorf("shot by %s has an invalid position %q, %q", raw.Player, raw.X, raw.Y)
+	}
+
+	shot := domain.DBXGStatShot{
+		PlayerName: raw.Player,
+		Situation:  understatSituations[raw.Situation],
+	}
+	shot.Minute, _ = strconv.Atoi(raw.Minute)
+	// Kept to three decimals like the xg column
+	if xg, err := strconv.ParseFloat(raw.XG, 64); err == nil {
+		shot.XG = roundXG(xg)
+	}
+
+	switch raw.Result {
+	case "Goal":
+		shot.IsGoal = true
+		shot.ShotType = "goal"
+	case "SavedShot":
+		shot.ShotType = "on_target"
+	case "BlockedShot":
+		shot.ShotType = "blocked"
+	default: // MissedShots, ShotOnPost
+		shot.ShotType = "off_target"
+	}
+
+	placeShot(&shot, coordinates.UnderstatPitch, x, y)
+	return shot, nil
+}
+
+func understatSideName(side string) string {
+	if side == "h" {
+		return "home"
+	}
+	return "away"
+}
+
+// embeddedJSON decodes a blob embedded as `var name = JSON.parse('...')`
+func embeddedJSON(pageData, name string, v interface{}) error {
+	pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\s*=\s*JSON\.parse\('((?:[^'\\]|\\.)*)'\)`)
+	m := pattern.FindStringSubmatch(pageData)
+	if m == nil {
+		return fmt.Errorf("%s not found on page", name)
+	}
+
+	decoded, err := unescapeJSString(m[1])
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if err := json.Unmarshal([]byte(decoded), v); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return nil
+}
+
+// unescapeJSString decodes the escapes of a single-quoted JavaScript string
+// literal; Understat hex-escapes most punctuation, e.g. \x7B for {
+func unescapeJSString(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i == len(s) {
+			return "", fmt.Errorf("trailing backslash")
+		}
+		switch c := s[i]; c {
+		case 'x', 'u':
+			digits := 2
+			if c == 'u' {
+				digits = 4
+			}
+			if i+digits >= len(s) {
+				return "", fmt.Errorf("short \\%c escape", c)
+			}
+			code, err := strconv.ParseUint(s[i+1:i+1+digits], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("invalid \\%c escape %q", c, s[i+1:i+1+digits])
+			}
+			b.WriteRune(rune(code))
+			i += digits
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		default: // \\, \', \" and \/ stand for themselves
+			b.WriteByte(c)
+		}
+	}
+	if !utf8.ValidString(b.String()) {
+		return "", fmt.Errorf("invalid UTF-8")
+	}
+	return b.String(), nil
+}
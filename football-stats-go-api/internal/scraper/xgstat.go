@@ -0,0 +1,350 @@
+package scraper
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // kick-off times are resolved in Europe/London even where the host has no zoneinfo
+
+	"example/hello/internal/coordinates"
+	"example/hello/internal/domain"
+
+	"golang.org/x/net/html"
+)
+
+// xgstatTimezone is the zone xgstat.com shows kick-off times in
+const xgstatTimezone = "Europe/London"
+
+var (
+	scorePattern    = regexp.MustCompile(`^(\d+)\s*-\s*(\d+)$`)
+	gameweekPattern = regexp.MustCompile(`^GW(\d+)$`)
+	kickoffPattern  = regexp.MustCompile(`^(\d{1,2})\s+([A-Za-z]{3})\s+(\d{1,2}:\d{2})$`)
+	seasonPattern   = regexp.MustCompile(`/(\d{4})-(\d{4})(?:/|$)`)
+)
+
+// ParseXGStatData parses the HTML of an xgstat.com shot map page into a DBXGStatFixture.
+// It has no side effects, so saved pages can be re-parsed without a browser.
+func ParseXGStatData(pageData, url string) (*domain.DBXGStatFixture, error) {
+	fixture, _, err := ParseXGStatReport(pageData, url)
+	return fixture, err
+}
+
+// ParseXGStatReport parses a shot map page like ParseXGStatData and also returns
+// a ParseReport describing which fields were found and how consistent they are.
+// The report is returned even when the fixture fails validation.
+func ParseXGStatReport(pageData, url string) (*domain.DBXGStatFixture, *ParseReport, error) {
+	doc, err := html.Parse(strings.NewReader(pageData))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	fixture := &domain.DBXGStatFixture{
+		Source:    domain.SourceXGStat,
+		HomeShots: []domain.DBXGStatShot{},
+		AwayShots: []domain.DBXGStatShot{},
+	}
+	report := newParseReport(url)
+
+	// Teams and score - the score <span class="font-bold"> 2 - 1 </span> sits
+	// between the home and away team links
+	if score := findFirst(doc, func(n *html.Node) bool {
+		return isElement(n, "span") && hasClass(n, "font-bold") && scorePattern.MatchString(textContent(n))
+	}); score != nil {
+		if home, away := prevElement(score), nextElement(score); home != nil && away != nil {
+			fixture.HomeTeam = teamName(home)
+			fixture.AwayTeam = teamName(away)
+			m := scorePattern.FindStringSubmatch(textContent(score))
+			fixture.HomeScore, _ = strconv.Atoi(m[1])
+			fixture.AwayScore, _ = strconv.Atoi(m[2])
+			report.found(fieldTeams, fixture.HomeTeam != "" && fixture.AwayTeam != "")
+			report.found(fieldScore, true)
+		}
+	}
+
+	// xG values - the first pair of tabular-nums spans in the same container, e.g. "1.25 - 0.87"
+	if first := findFirst(doc, func(n *html.Node) bool {
+		return isElement(n, "span") && hasClass(n, "tabular-nums")
+	}); first != nil {
+		values := findAll(first.Parent, func(n *html.Node) bool {
+			return isElement(n, "span") && hasClass(n, "tabular-nums")
+		})
+		if len(values) >= 2 {
+			homeXG, errHome := strconv.ParseFloat(textContent(values[0]), 64)
+			awayXG, errAway := strconv.ParseFloat(textContent(values[1]), 64)
+			fixture.HomeXG, fixture.AwayXG = homeXG, awayXG
+			report.found(fieldXG, errHome == nil && errAway == nil)
+		}
+	}
+
+	// Gameweek - a span reading "GW23"
+	if gw := findFirst(doc, func(n *html.Node) bool {
+		return isElement(n, "span") && gameweekPattern.MatchString(textContent(n))
+	}); gw != nil {
+		fixture.Gameweek, _ = strconv.Atoi(gameweekPattern.FindStringSubmatch(textContent(gw))[1])
+		report.found(fieldGameweek, true)
+	}
+
+	// Kick-off date - a span reading "25 Jan 16:30"; the year comes from the season
+	if kickoff := findFirst(doc, func(n *html.Node) bool {
+		return isElement(n, "span") && hasClass(n, "text-foreground", "text-nowrap") && kickoffPattern.MatchString(textContent(n))
+	}); kickoff != nil {
+		date, err := parseKickoff(textContent(kickoff), seasonStartYear(doc, url))
+		if err != nil {
+			report.issue("%v", err)
+		} else {
+			fixture.Date = date
+			report.found(fieldDate, true)
+		}
+	}
+
+	// xgstat.com shows no match ID, so the URL's slug is the key and the ID
+	// is derived from it
+	fixture.MatchKey = xgstatMatchSlug(url)
+	fixture.ID = XGStatMatchID(url)
+	report.found(fieldID, fixture.ID != 0)
+
+	// Shot maps - one card per team headed "<Team> xG Shot Map"
+	if section := shotMapSection(doc, fixture.HomeTeam); section != nil {
+		fixture.HomeShots = extractShotsFromSection(section)
+		report.found(fieldHomeShotMap, true)
+		report.Home = newTeamCheck(fixture.HomeXG, fixture.HomeShots, parsePlayerRows(section))
+	}
+	if section := shotMapSection(doc, fixture.AwayTeam); section != nil {
+		fixture.AwayShots = extractShotsFromSection(section)
+		report.found(fieldAwayShotMap, true)
+		report.Away = newTeamCheck(fixture.AwayXG, fixture.AwayShots, parsePlayerRows(section))
+	}
+
+	report.finish()
+
+	if err := validateFixture(fixture); err != nil {
+		return nil, report, err
+	}
+
+	return fixture, report, nil
+}
+
+// validateFixture rejects fixtures that cannot be stored meaningfully
+func validateFixture(fixture *domain.DBXGStatFixture) error {
+	if fixture.Date.IsZero() {
+		return fmt.Errorf("kick-off date not found on page")
+	}
+	return nil
+}
+
+// seasonStartYear returns the first year of the season ("2025-2026" -> 2025),
+// taken from the match URL or, failing that, from a competition link on the page
+func seasonStartYear(doc *html.Node, url string) int {
+	candidates := []string{url}
+	for _, link := range findAll(doc, func(n *html.Node) bool { return isElement(n, "a") }) {
+		candidates = append(candidates, attr(link, "href"))
+	}
+
+	for _, candidate := range candidates {
+		if m := seasonPattern.FindStringSubmatch(candidate); m != nil {
+			start, _ := strconv.Atoi(m[1])
+			end, _ := strconv.Atoi(m[2])
+			if end == start+1 {
+				return start
+			}
+		}
+	}
+	return 0
+}
+
+// parseKickoff resolves "25 Jan 16:30" to a UTC timestamp. Seasons run from
+// August to May, so July-December falls in the season's first year and
+// January-June in the second.
+func parseKickoff(text string, seasonStart int) (time.Time, error) {
+	if seasonStart == 0 {
+		return time.Time{}, fmt.Errorf("cannot resolve kick-off %q: season not found", text)
+	}
+
+	loc, err := time.LoadLocation(xgstatTimezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to load timezone %s: %w", xgstatTimezone, err)
+	}
+
+	m := kickoffPattern.FindStringSubmatch(text)
+	if m == nil {
+		return time.Time{}, fmt.Errorf("unrecognised kick-off %q", text)
+	}
+
+	dayMonth, err := time.Parse("2 Jan 15:04", m[1]+" "+m[2]+" "+m[3])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unrecognised kick-off %q: %w", text, err)
+	}
+
+	year := seasonStart
+	if dayMonth.Month() < time.July {
+		year++
+	}
+
+	kickoff := time.Date(year, dayMonth.Month(), dayMonth.Day(), dayMonth.Hour(), dayMonth.Minute(), 0, 0, loc)
+	return kickoff.UTC(), nil
+}
+
+// teamName returns the full team name from a header team link
+func teamName(link *html.Node) string {
+	if full := findFirst(link, func(n *html.Node) bool {
+		return isElement(n, "span") && hasClass(n, "hidden", "lg:inline")
+	}); full != nil {
+		return textContent(full)
+	}
+	return textContent(link)
+}
+
+// shotMapSection returns the card holding a team's shot map, i.e. the closest
+// ancestor of the "<Team> xG Shot Map" heading that also contains the SVG
+func shotMapSection(doc *html.Node, team string) *html.Node {
+	if team == "" {
+		return nil
+	}
+
+	heading := findFirst(doc, func(n *html.Node) bool {
+		return isElement(n, "h3") && textContent(n) == team+" xG Shot Map"
+	})
+	if heading == nil {
+		return nil
+	}
+
+	for n := heading.Parent; n != nil; n = n.Parent {
+		if findFirst(n, func(c *html.Node) bool { return isElement(c, "svg") }) != nil {
+			return n
+		}
+	}
+	return nil
+}
+
+// extractShotsFromSection extracts shot data from a team's shot map section.
+// Shot markers carry no player, minute or xG of their own and the player
+// table below the map only has per-player totals, so those fields stay empty;
+// sources with per-shot data (Understat, StatsBomb) fill them in.
+func extractShotsFromSection(section *html.Node) []domain.DBXGStatShot {
+	// Shot markers by type: off-target (fill="var(--foreground)" fill-opacity="0.3"),
+	// blocked (fill="var(--chart-red)"), on-target (fill-opacity="0.9"), goals (star svg)
+	var offTarget, blocked, onTarget, goals []domain.DBXGStatShot
+	system := shotMapSystem(section)
+
+	for _, circle := range findAll(section, func(n *html.Node) bool { return isElement(n, "circle") }) {
+		cx, errX := strconv.ParseFloat(attr(circle, "cx"), 64)
+		cy, errY := strconv.ParseFloat(attr(circle, "cy"), 64)
+		if errX != nil || errY != nil {
+			continue
+		}
+
+		shot := domain.DBXGStatShot{}
+		placeShot(&shot, system, cx, cy)
+
+		switch fill, opacity := attr(circle, "fill"), attr(circle, "fill-opacity"); {
+		case fill == "var(--chart-red)":
+			shot.ShotType = "blocked"
+			blocked = append(blocked, shot)
+		case fill == "var(--foreground)" && opacity == "0.3":
+			shot.ShotType = "off_target"
+			offTarget = append(offTarget, shot)
+		case fill == "var(--foreground)" && opacity == "0.9":
+			shot.ShotType = "on_target"
+			onTarget = append(onTarget, shot)
+		}
+	}
+
+	// Goals are nested star <svg> elements positioned by their top-left corner,
+	// so the shot location is the centre of the star's width/height box
+	for _, star := range findAll(section, isGoalStar) {
+		x, errX := strconv.ParseFloat(attr(star, "x"), 64)
+		y, errY := strconv.ParseFloat(attr(star, "y"), 64)
+		if errX != nil || errY != nil {
+			continue
+		}
+		width, _ := strconv.ParseFloat(attr(star, "width"), 64)
+		height, _ := strconv.ParseFloat(attr(star, "height"), 64)
+
+		shot := domain.DBXGStatShot{IsGoal: true, ShotType: "goal"}
+		placeShot(&shot, system, x+width/2, y+height/2)
+		goals = append(goals, shot)
+	}
+
+	shots := []domain.DBXGStatShot{}
+	shots = append(shots, offTarget...)
+	shots = append(shots, blocked...)
+	shots = append(shots, onTarget...)
+	shots = append(shots, goals...)
+
+	return shots
+}
+
+// isGoalStar matches the star <svg> drawn for each goal
+func isGoalStar(n *html.Node) bool {
+	return isElement(n, "svg") && attr(n, "fill") == "var(--brand-yellow)" && hasAttr(n, "x") && hasAttr(n, "y")
+}
+
+// shotMapSystem returns the coordinate system of the section's pitch drawing,
+// taking the extent from its viewBox when one is present
+func shotMapSystem(section *html.Node) coordinates.System {
+	pitch := findFirst(section, func(n *html.Node) bool {
+		return isElement(n, "svg") && hasAttr(n, "viewBox") && !isGoalStar(n)
+	})
+	if pitch == nil {
+		return coordinates.XGStatShotMap
+	}
+
+	system, err := coordinates.XGStatShotMap.WithViewBox(attr(pitch, "viewBox"))
+	if err != nil {
+		return coordinates.XGStatShotMap
+	}
+	return system
+}
+
+// placeShot stores the canonical 0-100 position and geometry of a marker drawn at x, y
+func placeShot(shot *domain.DBXGStatShot, system coordinates.System, x, y float64) {
+	p := system.Normalize(x, y).Percent()
+	shot.X, shot.Y = p.X, p.Y
+	shot.CoordinateSystem = system.Name
+	shot.DeriveGeometry()
+}
+
+// playerRow is one row of a team's shot map player table
+type playerRow struct {
+	Number int
+	Name   string
+	XG     float64
+	Goals  int
+	Shots  int
+}
+
+// parsePlayerRows reads the player table (number, name, xG, goals, shots) below a shot map.
+// Shots is -1 when the table has no shots column.
+func parsePlayerRows(section *html.Node) []playerRow {
+	var players []playerRow
+
+	for _, row := range findAll(section, func(n *html.Node) bool { return isElement(n, "tr") }) {
+		number := findFirst(row, func(n *html.Node) bool {
+			return isElement(n, "span") && hasClass(n, "size-4")
+		})
+		if number == nil {
+			continue
+		}
+		name := nextElement(number)
+		values := findAll(row, func(n *html.Node) bool {
+			return isElement(n, "div") && hasClass(n, "rounded")
+		})
+		if name == nil || len(values) < 2 {
+			continue
+		}
+
+		player := playerRow{Name: textContent(name)}
+		player.Number, _ = strconv.Atoi(textContent(number))
+		player.XG, _ = strconv.ParseFloat(textContent(values[0]), 64)
+		player.Goals, _ = strconv.Atoi(textContent(values[1]))
+		player.Shots = -1
+		if len(values) >= 3 {
+			player.Shots, _ = strconv.Atoi(textContent(values[2]))
+		}
+		players = append(players, player)
+	}
+
+	return players
+}
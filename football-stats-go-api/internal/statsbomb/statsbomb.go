@@ -0,0 +1,258 @@
+package statsbomb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"example/hello/internal/coordinates"
+	"example/hello/internal/domain"
+)
+
+// shootoutPeriod is the period of a penalty shoot-out, whose kicks are not shots
+const shootoutPeriod = 5
+
+// maxMinute is the latest minute the shots table accepts
+const maxMinute = 120
+
+// Match is one entry of a season's matches file
+type Match struct {
+	ID        int       `json:"id"`
+	Date      time.Time `json:"date"`
+	Week      int       `json:"week"`
+	HomeTeam  string    `json:"home_team"`
+	AwayTeam  string    `json:"away_team"`
+	HomeScore int       `json:"home_score"`
+	AwayScore int       `json:"away_score"`
+}
+
+// rawMatch is a match as StatsBomb writes it
+type rawMatch struct {
+	MatchID   int    `json:"match_id"`
+	MatchDate string `json:"match_date"`
+	KickOff   string `json:"kick_off"`
+	MatchWeek int    `json:"match_week"`
+	HomeScore int    `json:"home_score"`
+	AwayScore int    `json:"away_score"`
+	HomeTeam  struct {
+		Name string `json:"home_team_name"`
+	} `json:"home_team"`
+	AwayTeam struct {
+		Name string `json:"away_team_name"`
+	} `json:"away_team"`
+}
+
+// named is StatsBomb's {"id": 1, "name": "..."} reference
+type named struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// event is the part of an event used for shots
+type event struct {
+	Type        named     `json:"type"`
+	Period      int       `json:"period"`
+	Minute      int       `json:"minute"`
+	Team        named     `json:"team"`
+	Player      named     `json:"player"`
+	PlayPattern named     `json:"play_pattern"`
+	Location    []float64 `json:"location"`
+	Shot        *struct {
+		StatsBombXG float64 `json:"statsbomb_xg"`
+		Outcome     named   `json:"outcome"`
+		BodyPart    named   `json:"body_part"`
+		Type        named   `json:"type"`
+	} `json:"shot"`
+}
+
+// shotTypes maps shot outcomes to the domain shot types
+var shotTypes = map[string]string{
+	"Goal":             "goal",
+	"Saved":            "on_target",
+	"Saved To Post":    "on_target",
+	"Blocked":          "blocked",
+	"Off T":            "off_target",
+	"Post":             "off_target",
+	"Wayward":          "off_target",
+	"Saved Off Target": "off_target",
+}
+
+// bodyParts maps StatsBomb body parts to the domain ones
+var bodyParts = map[string]string{
+	"Right Foot": domain.BodyPartRightFoot,
+	"Left Foot":  domain.BodyPartLeftFoot,
+	"Head":       domain.BodyPartHead,
+	"Other":      domain.BodyPartOther,
+}
+
+// DataDir returns the directory of StatsBomb open-data files
+// (https://github.com/statsbomb/open-data), laid out as
+//
+//	<dir>/matches/<competition id>/<season id>.json
+//	<dir>/events/<match id>.json
+//
+// dir may be that directory or a checkout of the open-data repository.
+func DataDir(dir string) string {
+	if info, err := os.Stat(filepath.Join(dir, "data", "matches")); err == nil && info.IsDir() {
+		return filepath.Join(dir, "data")
+	}
+	return dir
+}
+
+// Matches reads the matches of one competition season
+func Matches(dir string, competitionID, seasonID int) ([]Match, error) {
+	path := filepath.Join(DataDir(dir), "matches", strconv.Itoa(competitionID), strconv.Itoa(seasonID)+".json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read matches: %w", err)
+	}
+
+	var raw []rawMatch
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid matches file %s: %w", path, err)
+	}
+
+	matches := make([]Match, 0, len(raw))
+	for _, m := range raw {
+		date, err := kickoff(m.MatchDate, m.KickOff)
+		if err != nil {
+			return nil, fmt.Errorf("match %d: %w", m.MatchID, err)
+		}
+		matches = append(matches, Match{
+			ID:        m.MatchID,
+			Date:      date,
+			Week:      m.MatchWeek,
+			HomeTeam:  m.HomeTeam.Name,
+			AwayTeam:  m.AwayTeam.Name,
+			HomeScore: m.HomeScore,
+			AwayScore: m.AwayScore,
+		})
+	}
+	return matches, nil
+}
+
+// Fixture reads a match's events file and converts its shots
+func Fixture(dir string, match Match) (*domain.DBXGStatFixture, error) {
+	data, err := os.ReadFile(filepath.Join(DataDir(dir), "events", strconv.Itoa(match.ID)+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no events for match %d", match.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+	return ParseEvents(match, data)
+}
+
+// ParseEvents converts the shot events of a match into a fixture. Team xG is
+// the sum of the shots' statsbomb_xg; penalty shoot-out kicks are left out.
+func ParseEvents(match Match, data []byte) (*domain.DBXGStatFixture, error) {
+	var events []event
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, fmt.Errorf("invalid events for match %d: %w", match.ID, err)
+	}
+
+	fixture := &domain.DBXGStatFixture{
+		Source:    domain.SourceStatsBomb,
+		Gameweek:  match.Week,
+		ID:        match.ID,
+		Date:      match.Date,
+		HomeTeam:  match.HomeTeam,
+		AwayTeam:  match.AwayTeam,
+		HomeScore: match.HomeScore,
+		AwayScore: match.AwayScore,
+		HomeShots: []domain.DBXGStatShot{},
+		AwayShots: []domain.DBXGStatShot{},
+	}
+
+	for _, e := range events {
+		if e.Type.Name != "Shot" || e.Shot == nil || e.Period == shootoutPeriod {
+			continue
+		}
+		if len(e.Location) < 2 {
+			return nil, fmt.Errorf("match %d: shot by %s in minute %d has no location", match.ID, e.Player.Name, e.Minute)
+		}
+
+		shot := domain.DBXGStatShot{
+			XG:          roundXG(e.Shot.StatsBombXG),
+			IsGoal:      e.Shot.Outcome.Name == "Goal",
+			ShotType:    shotTypes[e.Shot.Outcome.Name],
+			PlayerName:  e.Player.Name,
+			Minute:      min(e.Minute+1, maxMinute), // StatsBomb counts from minute 0
+			Situation:   situation(e.Shot.Type.Name, e.PlayPattern.Name),
+			BodyPart:    bodyParts[e.Shot.BodyPart.Name],
+			PlayPattern: slug(e.PlayPattern.Name),
+		}
+		if shot.ShotType == "" {
+			shot.ShotType = "off_target"
+		}
+
+		p := coordinates.StatsBombPitch.Normalize(e.Location[0], e.Location[1]).Percent()
+		shot.X, shot.Y = p.X, p.Y
+		shot.CoordinateSystem = coordinates.StatsBombPitch.Name
+		shot.DeriveGeometry()
+
+		switch e.Team.Name {
+		case match.HomeTeam:
+			fixture.HomeShots = append(fixture.HomeShots, shot)
+			fixture.HomeXG += shot.XG
+		case match.AwayTeam:
+			fixture.AwayShots = append(fixture.AwayShots, shot)
+			fixture.AwayXG += shot.XG
+		default:
+			return nil, fmt.Errorf("match %d: shot by unknown team %q", match.ID, e.Team.Name)
+		}
+	}
+
+	fixture.HomeXG = roundXG(fixture.HomeXG)
+	fixture.AwayXG = roundXG(fixture.AwayXG)
+	return fixture, nil
+}
+
+// situation derives the domain situation from the shot type, and for open
+// play shots from the pattern of play that led to them
+func situation(shotType, playPattern string) string {
+	switch shotType {
+	case "Penalty":
+		return domain.SituationPenalty
+	case "Free Kick":
+		return domain.SituationDirectFreeKick
+	case "Corner":
+		return domain.SituationCorner
+	}
+	switch playPattern {
+	case "From Corner":
+		return domain.SituationCorner
+	case "From Free Kick", "From Throw In":
+		return domain.SituationSetPiece
+	}
+	return domain.SituationOpenPlay
+}
+
+// kickoff combines a match date and kick-off time. StatsBomb gives local
+// times without a zone, so they are stored as UTC.
+func kickoff(date, kickOff string) (time.Time, error) {
+	if kickOff == "" {
+		kickOff = "00:00:00.000"
+	}
+	t, err := time.Parse("2006-01-02 15:04:05.000", date+" "+kickOff)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid kick-off %q %q", date, kickOff)
+	}
+	return t, nil
+}
+
+// slug turns a StatsBomb name such as "From Throw In" into from_throw_in
+func slug(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), " ", "_")
+}
+
+// roundXG keeps three decimals like the xg columns
+func roundXG(xg float64) float64 {
+	return math.Round(xg*1000) / 1000
+}
@@ -0,0 +1,183 @@
+package table
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+
+	"example/hello/internal/domain"
+)
+
+// Venues a table can be built for
+const (
+	VenueAll  = "all"
+	VenueHome = "home"
+	VenueAway = "away"
+)
+
+// Row is one team's line of the table. XPts are the points the team would
+// average if every match were replayed with the same shots. XPtsEstimated
+// counts the matches whose xPts come from the teams' xG instead, because
+// their shots carry none; for xgstat fixtures that is every match.
+type Row struct {
+	Position        int     `json:"position"`
+	Team            string  `json:"team"`
+	Played          int     `json:"played"`
+	Won             int     `json:"won"`
+	Drawn           int     `json:"drawn"`
+	Lost            int     `json:"lost"`
+	GoalsFor        int     `json:"goals_for"`
+	GoalsAgainst    int     `json:"goals_against"`
+	GoalDifference  int     `json:"goal_difference"`
+	Points          int     `json:"points"`
+	XGFor           float64 `json:"xg_for"`
+	XGAgainst       float64 `json:"xg_against"`
+	XPts            float64 `json:"xpts"`
+	PointsMinusXPts float64 `json:"points_minus_xpts"`
+	XPtsEstimated   int     `json:"xpts_estimated"`
+}
+
+// Probabilities are the chances of a match's results. Estimated is set when
+// a team's shots carry no xG and its total xG was used instead.
+type Probabilities struct {
+	HomeWin   float64 `json:"home_win"`
+	Draw      float64 `json:"draw"`
+	AwayWin   float64 `json:"away_win"`
+	Estimated bool    `json:"estimated"`
+}
+
+// GoalDistribution returns the chance of each number of goals from the
+// shots, each scored independently with its xG: index n holds P(n goals).
+// It is the Poisson binomial distribution, built one shot at a time.
+func GoalDistribution(shots []domain.DBXGStatShot) []float64 {
+	dist := make([]float64, 1, len(shots)+1)
+	dist[0] = 1
+	for _, shot := range shots {
+		p := min(max(shot.XG, 0), 1)
+		dist = append(dist, 0)
+		for n := len(dist) - 1; n > 0; n-- {
+			dist[n] = dist[n]*(1-p) + dist[n-1]*p
+		}
+		dist[0] *= 1 - p
+	}
+	return dist
+}
+
+// PoissonDistribution returns the chance of each number of goals for a team
+// that averages mean goals, up to the count beyond which the chance left is
+// negligible
+func PoissonDistribution(mean float64) []float64 {
+	p := math.Exp(-max(mean, 0))
+	dist := []float64{p}
+	for n, total := 1, p; total < 1-1e-9 && n <= 50; n++ {
+		p *= mean / float64(n)
+		dist = append(dist, p)
+		total += p
+	}
+	return dist
+}
+
+// teamDistribution returns a team's goal distribution from its shots. When
+// none of them carries xG (xgstat.com's markers have none) but the team has
+// xG, its goals are taken to be Poisson distributed around that xG and
+// estimated is true.
+func teamDistribution(shots []domain.DBXGStatShot, xg float64) (dist []float64, estimated bool) {
+	if xg > 0 && !slices.ContainsFunc(shots, func(shot domain.DBXGStatShot) bool { return shot.XG > 0 }) {
+		return PoissonDistribution(xg), true
+	}
+	return GoalDistribution(shots), false
+}
+
+// MatchProbabilities returns the result probabilities of a fixture from its
+// shots, or from a team's xG when its shots carry none. A fixture without
+// shots or xG is a certain draw.
+func MatchProbabilities(fixture domain.DBXGStatFixture) Probabilities {
+	home, homeEstimated := teamDistribution(fixture.HomeShots, fixture.HomeXG)
+	away, awayEstimated := teamDistribution(fixture.AwayShots, fixture.AwayXG)
+
+	probs := Probabilities{Estimated: homeEstimated || awayEstimated}
+	for h, ph := range home {
+		for a, pa := range away {
+			switch {
+			case h > a:
+				probs.HomeWin += ph * pa
+			case h < a:
+				probs.AwayWin += ph * pa
+			default:
+				probs.Draw += ph * pa
+			}
+		}
+	}
+	return probs
+}
+
+// Build builds the table of the fixtures for a venue: VenueHome counts only
+// each team's home matches, VenueAway only its away matches. Teams are ranked
+// by points, goal difference, goals scored and then name. Goals come from
+// the scores, so they include own goals, which no shot accounts for in xPts.
+func Build(fixtures []domain.DBXGStatFixture, venue string) []Row {
+	rows := map[string]*Row{}
+	add := func(team string, goalsFor, goalsAgainst int, xgFor, xgAgainst, winChance float64, probs Probabilities) {
+		row, ok := rows[team]
+		if !ok {
+			row = &Row{Team: team}
+			rows[team] = row
+		}
+		row.Played++
+		row.GoalsFor += goalsFor
+		row.GoalsAgainst += goalsAgainst
+		row.XGFor += xgFor
+		row.XGAgainst += xgAgainst
+		row.XPts += 3*winChance + probs.Draw
+		if probs.Estimated {
+			row.XPtsEstimated++
+		}
+		switch {
+		case goalsFor > goalsAgainst:
+			row.Won++
+		case goalsFor < goalsAgainst:
+			row.Lost++
+		default:
+			row.Drawn++
+		}
+	}
+
+	for _, f := range fixtures {
+		probs := MatchProbabilities(f)
+		if venue != VenueAway {
+			add(f.HomeTeam, f.HomeScore, f.AwayScore, f.HomeXG, f.AwayXG, probs.HomeWin, probs)
+		}
+		if venue != VenueHome {
+			add(f.AwayTeam, f.AwayScore, f.HomeScore, f.AwayXG, f.HomeXG, probs.AwayWin, probs)
+		}
+	}
+
+	table := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		row.Points = 3*row.Won + row.Drawn
+		row.GoalDifference = row.GoalsFor - row.GoalsAgainst
+		row.XGFor = round2(row.XGFor)
+		row.XGAgainst = round2(row.XGAgainst)
+		row.XPts = round2(row.XPts)
+		row.PointsMinusXPts = round2(float64(row.Points) - row.XPts)
+		table = append(table, *row)
+	}
+
+	slices.SortFunc(table, func(a, b Row) int {
+		return cmp.Or(
+			cmp.Compare(b.Points, a.Points),
+			cmp.Compare(b.GoalDifference, a.GoalDifference),
+			cmp.Compare(b.GoalsFor, a.GoalsFor),
+			strings.Compare(a.Team, b.Team),
+		)
+	})
+	for i := range table {
+		table[i].Position = i + 1
+	}
+	return table
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1 @@
+ALTER TABLE xgstat_shots DROP COLUMN IF EXISTS coordinate_system;
@@ -0,0 +1,4 @@
+-- Record the source coordinate system shots were converted from.
+-- x/y now hold canonical 0-100 positions (attacking left to right);
+-- rows saved before this migration keep their raw SVG values and a NULL system.
+ALTER TABLE xgstat_shots ADD COLUMN IF NOT EXISTS coordinate_system VARCHAR(50);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_xgstat_shots_zone;
+
+ALTER TABLE xgstat_shots
+    DROP CONSTRAINT IF EXISTS valid_angle,
+    DROP COLUMN IF EXISTS zone,
+    DROP COLUMN IF EXISTS angle,
+    DROP COLUMN IF EXISTS distance;
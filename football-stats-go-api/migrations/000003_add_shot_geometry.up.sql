@@ -0,0 +1,11 @@
+-- Shot geometry derived from the canonical coordinates
+ALTER TABLE xgstat_shots
+    ADD COLUMN IF NOT EXISTS distance DECIMAL(6, 3),
+    ADD COLUMN IF NOT EXISTS angle DECIMAL(6, 3),
+    ADD COLUMN IF NOT EXISTS zone VARCHAR(50);
+
+ALTER TABLE xgstat_shots
+    ADD CONSTRAINT valid_angle CHECK (angle >= 0 AND angle <= 180);
+
+-- Create index on zone for zone breakdowns
+CREATE INDEX IF NOT EXISTS idx_xgstat_shots_zone ON xgstat_shots(zone);
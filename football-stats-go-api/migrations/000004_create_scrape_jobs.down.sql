@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_scrape_jobs_status;
+DROP TABLE IF EXISTS scrape_jobs;
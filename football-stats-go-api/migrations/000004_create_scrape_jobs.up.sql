@@ -0,0 +1,16 @@
+-- Create scrape_jobs table for asynchronous scrapes
+CREATE TABLE IF NOT EXISTS scrape_jobs (
+    id VARCHAR(36) PRIMARY KEY,
+    url TEXT NOT NULL,
+    strict BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('queued', 'running', 'succeeded', 'failed')),
+    fixture JSONB,
+    report JSONB,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+-- Create index on status so unfinished jobs can be resumed quickly
+CREATE INDEX IF NOT EXISTS idx_scrape_jobs_status ON scrape_jobs(status);
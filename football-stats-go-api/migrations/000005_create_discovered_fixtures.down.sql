@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_discovered_fixtures_round;
+DROP INDEX IF EXISTS idx_discovered_fixtures_status;
+DROP TABLE IF EXISTS discovered_fixtures;
@@ -0,0 +1,19 @@
+-- Create discovered_fixtures table for match pages found on listing pages
+CREATE TABLE IF NOT EXISTS discovered_fixtures (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL UNIQUE,
+    fixture_id INT NOT NULL,
+    competition VARCHAR(100) NOT NULL,
+    season VARCHAR(9) NOT NULL,
+    gameweek INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'scraped', 'failed')),
+    error TEXT,
+    discovered_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    scraped_at TIMESTAMP
+);
+
+-- Create index on status for picking up pending fixtures
+CREATE INDEX IF NOT EXISTS idx_discovered_fixtures_status ON discovered_fixtures(status);
+
+-- Create index on competition, season and gameweek for listing a round
+CREATE INDEX IF NOT EXISTS idx_discovered_fixtures_round ON discovered_fixtures(competition, season, gameweek);
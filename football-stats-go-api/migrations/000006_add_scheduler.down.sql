@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS scheduler_runs;
+
+DROP INDEX IF EXISTS idx_discovered_fixtures_kickoff;
+
+ALTER TABLE discovered_fixtures
+    DROP COLUMN IF EXISTS kickoff;
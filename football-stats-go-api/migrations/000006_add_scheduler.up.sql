@@ -0,0 +1,17 @@
+-- Kick-off of discovered fixtures, used to decide when a match can be scraped
+ALTER TABLE discovered_fixtures
+    ADD COLUMN IF NOT EXISTS kickoff TIMESTAMP;
+
+-- Create index on kickoff for finding fixtures due for scraping
+CREATE INDEX IF NOT EXISTS idx_discovered_fixtures_kickoff ON discovered_fixtures(kickoff);
+
+-- Create scheduler_runs table holding the last run of each scheduled task
+CREATE TABLE IF NOT EXISTS scheduler_runs (
+    name VARCHAR(100) PRIMARY KEY,
+    last_slot TIMESTAMP NOT NULL,
+    last_started_at TIMESTAMP NOT NULL,
+    last_finished_at TIMESTAMP,
+    last_duration_ms BIGINT,
+    last_status VARCHAR(20) NOT NULL CHECK (last_status IN ('running', 'succeeded', 'failed')),
+    last_error TEXT
+);
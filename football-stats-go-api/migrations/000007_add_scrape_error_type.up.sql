@@ -0,0 +1,2 @@
+-- Record the class of a failed scrape (not_found, blocked, timeout or parse)
+ALTER TABLE scrape_jobs ADD COLUMN IF NOT EXISTS error_type VARCHAR(20);
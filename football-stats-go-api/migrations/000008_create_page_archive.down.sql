@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_page_archive_url_fetched_at;
+DROP TABLE IF EXISTS page_archive;
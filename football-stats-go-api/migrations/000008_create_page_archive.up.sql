@@ -0,0 +1,12 @@
+-- Create page_archive table holding the raw HTML of every fetched page
+CREATE TABLE IF NOT EXISTS page_archive (
+    id BIGSERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    fetched_at TIMESTAMP NOT NULL,
+    content_hash CHAR(64) NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    html_gzip BYTEA NOT NULL
+);
+
+-- Create index so the latest copy of a URL is found quickly
+CREATE INDEX IF NOT EXISTS idx_page_archive_url_fetched_at ON page_archive(url, fetched_at DESC);
@@ -0,0 +1,9 @@
+ALTER TABLE xgstat_shots
+    DROP COLUMN IF EXISTS situation;
+
+DELETE FROM xgstat_fixtures WHERE source <> 'xgstat';
+
+ALTER TABLE xgstat_fixtures
+    DROP CONSTRAINT IF EXISTS xgstat_fixtures_source_fixture_id_key,
+    ADD CONSTRAINT xgstat_fixtures_fixture_id_gameweek_key UNIQUE (fixture_id, gameweek),
+    DROP COLUMN IF EXISTS source;
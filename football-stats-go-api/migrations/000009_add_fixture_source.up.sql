@@ -0,0 +1,11 @@
+-- Fixtures can come from more than one site; the same match is stored once per source
+ALTER TABLE xgstat_fixtures
+    ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'xgstat';
+
+ALTER TABLE xgstat_fixtures
+    DROP CONSTRAINT IF EXISTS xgstat_fixtures_fixture_id_gameweek_key,
+    ADD CONSTRAINT xgstat_fixtures_source_fixture_id_key UNIQUE (source, fixture_id);
+
+-- How the shot came about (open_play, corner, set_piece, direct_free_kick, penalty)
+ALTER TABLE xgstat_shots
+    ADD COLUMN IF NOT EXISTS situation VARCHAR(30);
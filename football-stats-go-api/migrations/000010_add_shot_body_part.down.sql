@@ -0,0 +1,3 @@
+ALTER TABLE xgstat_shots
+    DROP COLUMN IF EXISTS play_pattern,
+    DROP COLUMN IF EXISTS body_part;
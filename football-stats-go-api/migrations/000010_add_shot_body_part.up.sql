@@ -0,0 +1,4 @@
+-- Shot details reported by event data such as StatsBomb's
+ALTER TABLE xgstat_shots
+    ADD COLUMN IF NOT EXISTS body_part VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS play_pattern VARCHAR(30);
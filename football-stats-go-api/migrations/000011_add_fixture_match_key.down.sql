@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_xgstat_fixtures_source_fixture_id;
+
+-- Version 10 allows one fixture per source and ID, but matches keyed on
+-- match_key can share an ID. The most recently updated one is kept; the
+-- others are deleted with their shots.
+DELETE FROM xgstat_fixtures f
+USING (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY source, fixture_id ORDER BY updated_at DESC NULLS LAST, id DESC) AS n
+    FROM xgstat_fixtures
+) ranked
+WHERE ranked.id = f.id AND ranked.n > 1;
+
+-- fixture_id stays BIGINT, as IDs derived from xgstat slugs do not fit in INT
+ALTER TABLE xgstat_fixtures
+    DROP CONSTRAINT IF EXISTS xgstat_fixtures_source_match_key_key,
+    DROP COLUMN IF EXISTS match_key,
+    ADD CONSTRAINT xgstat_fixtures_source_fixture_id_key UNIQUE (source, fixture_id);
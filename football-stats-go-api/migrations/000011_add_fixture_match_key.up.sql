@@ -0,0 +1,45 @@
+-- Fixtures are keyed on the source's own name for a match: the URL slug on
+-- xgstat.com, whose fixture IDs are derived from it, and the numeric match ID
+-- elsewhere. Derived IDs need more than 32 bits.
+--
+-- xgstat fixture IDs used to be the match's day of the month, which repeats
+-- across gameweeks, so neither (source, fixture_id) from 000009 nor the
+-- (source, fixture_id, gameweek) key of databases recovered by hand from a
+-- failed 000009 (see MIGRATIONS.md) can identify a match. Both are replaced.
+ALTER TABLE xgstat_fixtures
+    ADD COLUMN IF NOT EXISTS match_key VARCHAR(255),
+    ALTER COLUMN fixture_id TYPE BIGINT;
+
+-- xgstat fixtures saved earlier have the day of the month as their ID. Their
+-- slug is rebuilt from the teams and the kick-off date in London, so scraping
+-- or re-parsing the match again updates the same row.
+UPDATE xgstat_fixtures
+SET match_key = CASE
+    WHEN source = 'xgstat' THEN
+        trim(BOTH '-' FROM regexp_replace(lower(home_team || '-' || away_team), '[^a-z0-9]+', '-', 'g'))
+        || '-' || to_char(fixture_date AT TIME ZONE 'UTC' AT TIME ZONE 'Europe/London', 'YYYY-MM-DD')
+    ELSE fixture_id::TEXT
+END
+WHERE match_key IS NULL;
+
+-- Rows that rebuild to the same key all stay; all but the newest get their row ID appended
+UPDATE xgstat_fixtures f
+SET match_key = f.match_key || '-' || f.id
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY source, match_key ORDER BY updated_at DESC, id DESC) AS n
+    FROM xgstat_fixtures
+) ranked
+WHERE ranked.id = f.id AND ranked.n > 1;
+
+ALTER TABLE xgstat_fixtures
+    ALTER COLUMN match_key SET NOT NULL,
+    DROP CONSTRAINT IF EXISTS xgstat_fixtures_source_fixture_id_key,
+    DROP CONSTRAINT IF EXISTS xgstat_fixtures_source_fixture_id_gameweek_key,
+    ADD CONSTRAINT xgstat_fixtures_source_match_key_key UNIQUE (source, match_key);
+
+-- Create index so fixtures are still found by their ID
+CREATE INDEX IF NOT EXISTS idx_xgstat_fixtures_source_fixture_id ON xgstat_fixtures(source, fixture_id);
+
+-- Discovered xgstat fixtures get the same slug-derived IDs
+ALTER TABLE discovered_fixtures
+    ALTER COLUMN fixture_id TYPE BIGINT;
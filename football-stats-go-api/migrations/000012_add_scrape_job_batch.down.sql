@@ -0,0 +1,3 @@
+ALTER TABLE scrape_jobs
+    DROP COLUMN IF EXISTS jobs,
+    DROP COLUMN IF EXISTS batch;
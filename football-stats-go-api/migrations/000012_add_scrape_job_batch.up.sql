@@ -0,0 +1,5 @@
+-- A batch job lists the match pages at its URL and queues a scrape job for
+-- each; jobs holds the IDs of the jobs it queued
+ALTER TABLE scrape_jobs
+    ADD COLUMN IF NOT EXISTS batch BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS jobs JSONB;
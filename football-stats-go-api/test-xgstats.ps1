@@ -2,6 +2,16 @@
 Write-Host "Testing xG Stats Scraper Endpoint..." -ForegroundColor Cyan
 
 $url = "http://localhost:8080/api/scrape/xgstats"
+$jobsUrl = "http://localhost:8080/api/scrape/jobs"
+
+# The scrape runs as a background job; poll it until it finishes
+function Wait-ScrapeJob($jobId) {
+    do {
+        Start-Sleep -Seconds 2
+        $job = Invoke-RestMethod -Uri "$jobsUrl/$jobId" -Method Get
+    } while ($job.data.status -eq "queued" -or $job.data.status -eq "running")
+    return $job
+}
 
 # Example URL from xgstat.com
 $body = @{
@@ -13,14 +23,16 @@ Write-Host "Request Body:" -ForegroundColor Yellow
 Write-Host $body -ForegroundColor Gray
 
 try {
-    $response = Invoke-RestMethod -Uri $url -Method Post -Body $body -ContentType "application/json"
+    $accepted = Invoke-RestMethod -Uri $url -Method Post -Body $body -ContentType "application/json"
+    Write-Host "`nQueued job $($accepted.data.id), waiting for it to finish..." -ForegroundColor Yellow
+    $response = Wait-ScrapeJob $accepted.data.id
     
-    Write-Host "`n✅ Success!" -ForegroundColor Green
+    Write-Host "`n✅ Job $($response.data.status)" -ForegroundColor Green
     Write-Host "`nResponse:" -ForegroundColor Cyan
     $response | ConvertTo-Json -Depth 10
     
-    if ($response.success) {
-        $data = $response.data
+    if ($response.data.fixture) {
+        $data = $response.data.fixture
         Write-Host "`n📊 Match Summary:" -ForegroundColor Magenta
         Write-Host "  $($data.home_team) $($data.home_score) - $($data.away_score) $($data.away_team)" -ForegroundColor White
         Write-Host "  xG: $($data.home_xg) - $($data.away_xg)" -ForegroundColor Yellow
@@ -47,12 +59,13 @@ $body2 = @{
 } | ConvertTo-Json
 
 try {
-    $response2 = Invoke-RestMethod -Uri $url -Method Post -Body $body2 -ContentType "application/json"
+    $accepted2 = Invoke-RestMethod -Uri $url -Method Post -Body $body2 -ContentType "application/json"
+    $response2 = Wait-ScrapeJob $accepted2.data.id
     
-    Write-Host "✅ Success!" -ForegroundColor Green
+    Write-Host "✅ Job $($response2.data.status)" -ForegroundColor Green
     
-    if ($response2.success) {
-        $data2 = $response2.data
+    if ($response2.data.fixture) {
+        $data2 = $response2.data.fixture
         Write-Host "`n📊 Match Summary:" -ForegroundColor Magenta
         Write-Host "  $($data2.home_team) $($data2.home_score) - $($data2.away_score) $($data2.away_team)" -ForegroundColor White
         Write-Host "  xG: $($data2.home_xg) - $($data2.away_xg)" -ForegroundColor Yellow
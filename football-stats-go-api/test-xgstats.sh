@@ -4,6 +4,7 @@
 echo -e "\033[0;36mTesting xG Stats Scraper Endpoint...\033[0m"
 
 URL="http://localhost:8080/api/scrape/xgstats"
+JOBS_URL="http://localhost:8080/api/scrape/jobs"
 
 # Example URL from xgstat.com
 BODY='{
@@ -19,20 +20,30 @@ RESPONSE=$(curl -s -X POST "$URL" \
   -d "$BODY")
 
 if [ $? -eq 0 ]; then
-    echo -e "\n\033[0;32m✅ Success!\033[0m"
+    # The scrape runs as a background job; poll it until it finishes
+    JOB_ID=$(echo "$RESPONSE" | jq -r '.data.id')
+    echo -e "\n\033[0;33mQueued job $JOB_ID, waiting for it to finish...\033[0m"
+    STATUS="queued"
+    while [ "$STATUS" = "queued" ] || [ "$STATUS" = "running" ]; do
+        sleep 2
+        RESPONSE=$(curl -s "$JOBS_URL/$JOB_ID")
+        STATUS=$(echo "$RESPONSE" | jq -r '.data.status')
+    done
+
+    echo -e "\n\033[0;32m✅ Job $STATUS\033[0m"
     echo -e "\n\033[0;36mResponse:\033[0m"
     echo "$RESPONSE" | jq '.'
     
     # Extract and display match summary
-    HOME_TEAM=$(echo "$RESPONSE" | jq -r '.data.home_team')
-    AWAY_TEAM=$(echo "$RESPONSE" | jq -r '.data.away_team')
-    HOME_SCORE=$(echo "$RESPONSE" | jq -r '.data.home_score')
-    AWAY_SCORE=$(echo "$RESPONSE" | jq -r '.data.away_score')
-    HOME_XG=$(echo "$RESPONSE" | jq -r '.data.home_xg')
-    AWAY_XG=$(echo "$RESPONSE" | jq -r '.data.away_xg')
-    GAMEWEEK=$(echo "$RESPONSE" | jq -r '.data.gameweek')
-    HOME_SHOTS=$(echo "$RESPONSE" | jq -r '.data.home_shots | length')
-    AWAY_SHOTS=$(echo "$RESPONSE" | jq -r '.data.away_shots | length')
+    HOME_TEAM=$(echo "$RESPONSE" | jq -r '.data.fixture.home_team')
+    AWAY_TEAM=$(echo "$RESPONSE" | jq -r '.data.fixture.away_team')
+    HOME_SCORE=$(echo "$RESPONSE" | jq -r '.data.fixture.home_score')
+    AWAY_SCORE=$(echo "$RESPONSE" | jq -r '.data.fixture.away_score')
+    HOME_XG=$(echo "$RESPONSE" | jq -r '.data.fixture.home_xg')
+    AWAY_XG=$(echo "$RESPONSE" | jq -r '.data.fixture.away_xg')
+    GAMEWEEK=$(echo "$RESPONSE" | jq -r '.data.fixture.gameweek')
+    HOME_SHOTS=$(echo "$RESPONSE" | jq -r '.data.fixture.home_shots | length')
+    AWAY_SHOTS=$(echo "$RESPONSE" | jq -r '.data.fixture.away_shots | length')
     
     if [ "$HOME_TEAM" != "null" ]; then
         echo -e "\n\033[0;35m📊 Match Summary:\033[0m"
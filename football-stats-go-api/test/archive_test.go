@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"example/hello/internal/archive"
+	"example/hello/internal/scraper"
+)
+
+func TestFileArchive(t *testing.T) {
+	store := archive.NewFileStore(t.TempDir())
+	ctx := context.Background()
+	url := "https://www.xgstat.com/competitions/premier-league/2025-2026/matches/arsenal-man-utd-2026-01-24/advanced-analysis/shot-maps"
+	first := time.Date(2026, 1, 24, 18, 0, 0, 0, time.UTC)
+
+	if err := store.SavePage(ctx, url, first, "<html>v1</html>"); err != nil {
+		t.Fatalf("SavePage returned error: %v", err)
+	}
+	// The same content again is not stored twice
+	if err := store.SavePage(ctx, url, first.Add(time.Hour), "<html>v1</html>"); err != nil {
+		t.Fatalf("SavePage returned error: %v", err)
+	}
+
+	entries, err := store.LatestPages(ctx, archive.Filter{})
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("LatestPages = %v, %v; want one entry", entries, err)
+	}
+	if entries[0].URL != url || !entries[0].FetchedAt.Equal(first) || entries[0].Hash != archive.Hash("<html>v1</html>") || entries[0].Size != 15 {
+		t.Errorf("entry = %+v", entries[0])
+	}
+
+	second := first.Add(2 * time.Hour)
+	if err := store.SavePage(ctx, url, second, "<html>v2</html>"); err != nil {
+		t.Fatalf("SavePage returned error: %v", err)
+	}
+	if err := store.SavePage(ctx, "https://www.xgstat.com/other", first, "<html>other</html>"); err != nil {
+		t.Fatalf("SavePage returned error: %v", err)
+	}
+
+	entries, err = store.LatestPages(ctx, archive.Filter{URL: url})
+	if err != nil || len(entries) != 1 || !entries[0].FetchedAt.Equal(second) {
+		t.Fatalf("LatestPages(url) = %+v, %v; want the second copy", entries, err)
+	}
+	html, err := store.LoadPage(ctx, entries[0])
+	if err != nil || html != "<html>v2</html>" {
+		t.Errorf("LoadPage = %q, %v; want v2", html, err)
+	}
+
+	entries, err = store.LatestPages(ctx, archive.Filter{Since: first.Add(time.Minute)})
+	if err != nil || len(entries) != 1 || entries[0].URL != url {
+		t.Errorf("LatestPages(since) = %+v, %v; want only %s", entries, err, url)
+	}
+}
+
+func TestScrapeArchivesPage(t *testing.T) {
+	store := archive.NewFileStore(t.TempDir())
+	service := scraper.NewServiceWithFetcher(savedPageFetcher{}, time.Second, false)
+	service.SetArchiver(store)
+	ctx := context.Background()
+
+	scraped, _, err := service.ScrapeXGStatFixture(ctx, parserCases[0].url)
+	if err != nil {
+		t.Fatalf("scrape failed: %v", err)
+	}
+
+	entries, err := store.LatestPages(ctx, archive.Filter{URL: parserCases[0].url})
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("LatestPages = %v, %v; want the scraped page", entries, err)
+	}
+	html, err := store.LoadPage(ctx, entries[0])
+	if err != nil {
+		t.Fatalf("LoadPage returned error: %v", err)
+	}
+
+	reparsed, report, err := scraper.ParseXGStatReport(html, entries[0].URL)
+	if err != nil {
+		t.Fatalf("re-parse failed: %v", err)
+	}
+	if !report.Complete || reparsed.HomeTeam != scraped.HomeTeam || len(reparsed.HomeShots) != len(scraped.HomeShots) {
+		t.Errorf("re-parsed %+v, want the scraped fixture", reparsed)
+	}
+
+	// A later bot check is not archived, so the good copy stays the latest
+	blocked := scraper.NewServiceWithFetcher(scraper.FetcherFunc(func(ctx context.Context, url string) (string, error) {
+		return "<html><head><title>Just a moment...</title></head></html>", nil
+	}), time.Second, false)
+	blocked.SetArchiver(store)
+	if _, _, err := blocked.ScrapeXGStatFixture(ctx, parserCases[0].url); !errors.Is(err, scraper.ErrBlocked) {
+		t.Fatalf("scrape of a bot check: %v, want ErrBlocked", err)
+	}
+	latest, err := store.LatestPages(ctx, archive.Filter{URL: parserCases[0].url})
+	if err != nil || len(latest) != 1 || latest[0].Hash != entries[0].Hash {
+		t.Errorf("LatestPages after a bot check = %v, %v; want the scraped page", latest, err)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"example/hello/internal/coordinates"
+	"example/hello/internal/domain"
+)
+
+func TestNormalize(t *testing.T) {
+	horizontal := coordinates.System{
+		Name:        "fraction",
+		Width:       1,
+		Height:      1,
+		Orientation: coordinates.AttackingRight,
+	}
+
+	tests := []struct {
+		name   string
+		system coordinates.System
+		x, y   float64
+		want   coordinates.Point
+	}{
+		{"xgstat goal centre", coordinates.XGStatShotMap, 34, 0, coordinates.Point{X: 105, Y: 34}},
+		{"xgstat penalty spot", coordinates.XGStatShotMap, 34, 11, coordinates.Point{X: 94, Y: 34}},
+		{"xgstat halfway line left", coordinates.XGStatShotMap, 0, 52.5, coordinates.Point{X: 52.5, Y: 0}},
+		{"xgstat marker beyond the goal line is clamped", coordinates.XGStatShotMap, 34, -1, coordinates.Point{X: 105, Y: 34}},
+		{"full pitch fraction", horizontal, 0.9, 0.25, coordinates.Point{X: 94.5, Y: 17}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.system.Normalize(tt.x, tt.y)
+			if math.Abs(got.X-tt.want.X) > 1e-9 || math.Abs(got.Y-tt.want.Y) > 1e-9 {
+				t.Errorf("Normalize(%v, %v) = %+v, want %+v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithViewBox(t *testing.T) {
+	system, err := coordinates.XGStatShotMap.WithViewBox("0 0 680 525")
+	if err != nil {
+		t.Fatalf("WithViewBox returned error: %v", err)
+	}
+
+	if got := system.Normalize(340, 110).Percent(); got != (coordinates.Point{X: 89.524, Y: 50}) {
+		t.Errorf("scaled viewBox gave %+v", got)
+	}
+
+	if _, err := coordinates.XGStatShotMap.WithViewBox("0 0 68"); err == nil {
+		t.Error("expected an error for a short viewBox")
+	}
+}
+
+func TestDeriveGeometry(t *testing.T) {
+	tests := []struct {
+		name         string
+		x, y         float64 // canonical 0-100
+		wantDistance float64
+		wantAngle    float64
+		wantZone     string
+	}{
+		{"penalty spot", 100 * 94 / 105.0, 50, 11, 36.807, domain.ZonePenaltyAreaCentral},
+		{"inside six-yard box", 100 * 101 / 105.0, 50, 4, 84.917, domain.ZoneSixYardBox},
+		{"wide in the box", 100 * 95 / 105.0, 100 * 50 / 68.0, 18.868, 12.06, domain.ZonePenaltyAreaWide},
+		{"edge of the box", 100 * 85 / 105.0, 50, 20, 20.741, domain.ZoneOutsideBox},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shot := domain.DBXGStatShot{X: tt.x, Y: tt.y}
+			shot.DeriveGeometry()
+
+			if math.Abs(shot.Distance-tt.wantDistance) > 0.001 {
+				t.Errorf("distance = %v, want %v", shot.Distance, tt.wantDistance)
+			}
+			if math.Abs(shot.Angle-tt.wantAngle) > 0.001 {
+				t.Errorf("angle = %v, want %v", shot.Angle, tt.wantAngle)
+			}
+			if shot.Zone != tt.wantZone {
+				t.Errorf("zone = %q, want %q", shot.Zone, tt.wantZone)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"example/hello/internal/domain"
+	"example/hello/internal/jobs"
+	"example/hello/internal/scraper"
+)
+
+// listingFetcher serves gameweek listing pages from memory
+type listingFetcher map[string]string
+
+func (f listingFetcher) Fetch(ctx context.Context, url string) (string, error) {
+	page, ok := f[url]
+	if !ok {
+		return "", fmt.Errorf("unexpected status 404")
+	}
+	return page, nil
+}
+
+// memoryDiscoveryStore keeps discovered fixtures keyed by URL
+type memoryDiscoveryStore struct {
+	mu       sync.Mutex
+	fixtures map[string]domain.DBDiscoveredFixture
+}
+
+func (s *memoryDiscoveryStore) SaveDiscoveredFixtures(ctx context.Context, fixtures []domain.DBDiscoveredFixture) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := 0
+	for _, fixture := range fixtures {
+		if _, ok := s.fixtures[fixture.URL]; !ok {
+			s.fixtures[fixture.URL] = fixture
+			added++
+		}
+	}
+	return added, nil
+}
+
+func (s *memoryDiscoveryStore) ListDiscoveredFixtures(ctx context.Context, filter domain.DiscoveryFilter) ([]domain.DBDiscoveredFixture, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var fixtures []domain.DBDiscoveredFixture
+	for _, fixture := range s.fixtures {
+		if filter.Status == "" || fixture.Status == filter.Status {
+			fixtures = append(fixtures, fixture)
+		}
+	}
+	return fixtures, nil
+}
+
+func listingPage(slugs ...string) string {
+	page := "<html><body>"
+	for _, slug := range slugs {
+		page += fmt.Sprintf(`<a href="/competitions/premier-league/2025-2026/matches/%s">%s</a>`, slug, slug)
+	}
+	return page + "</body></html>"
+}
+
+func TestDiscover(t *testing.T) {
+	fetcher := listingFetcher{
+		scraper.GameweekListingURL("premier-league", "2025-2026", 1): listingPage("liverpool-bournemouth-2025-08-15", "aston-villa-newcastle-2025-08-16"),
+		scraper.GameweekListingURL("premier-league", "2025-2026", 2): listingPage("west-ham-chelsea-2025-08-22"),
+	}
+	service := scraper.NewServiceWithFetcher(fetcher, time.Second, false)
+	store := &memoryDiscoveryStore{fixtures: make(map[string]domain.DBDiscoveredFixture)}
+
+	result := jobs.Discover(context.Background(), service, store, "premier-league", "2025-2026", 1, 3)
+
+	if result.Gameweeks != 3 || result.Found != 3 || result.Added != 3 {
+		t.Errorf("got %d gameweeks, %d found, %d added; want 3, 3, 3", result.Gameweeks, result.Found, result.Added)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("got errors %v, want one for the missing gameweek 3", result.Errors)
+	}
+
+	url := scraper.ShotMapURL("premier-league", "2025-2026", "west-ham-chelsea-2025-08-22")
+	fixture, ok := store.fixtures[url]
+	if !ok {
+		t.Fatalf("fixture %s was not stored", url)
+	}
+	if fixture.Gameweek != 2 || fixture.Status != domain.DiscoveryPending || fixture.FixtureID != scraper.XGStatMatchID(url) {
+		t.Errorf("stored fixture = %+v", fixture)
+	}
+
+	again := jobs.Discover(context.Background(), service, store, "premier-league", "2025-2026", 1, 2)
+	if again.Found != 3 || again.Added != 0 {
+		t.Errorf("re-crawl found %d and added %d, want 3 and 0", again.Found, again.Added)
+	}
+
+	urls, err := jobs.PendingURLs(context.Background(), store, domain.DiscoveryFilter{})
+	if err != nil || len(urls) != 3 {
+		t.Errorf("PendingURLs = %v, %v; want 3 URLs", urls, err)
+	}
+}
+
+// recordingDiscoverySaver saves nothing but remembers each scrape outcome
+type recordingDiscoverySaver struct {
+	mu       sync.Mutex
+	outcomes map[string]error
+}
+
+func (s *recordingDiscoverySaver) SaveXGStatFixture(ctx context.Context, fixture *domain.DBXGStatFixture) error {
+	return nil
+}
+
+func (s *recordingDiscoverySaver) RecordScrape(ctx context.Context, url string, fixture *domain.DBXGStatFixture, scrapeErr error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outcomes[url] = scrapeErr
+	return nil
+}
+
+func TestRunBatchRecordsOutcomes(t *testing.T) {
+	service := scraper.NewServiceWithFetcher(savedPageFetcher{}, time.Second, false)
+	saver := &recordingDiscoverySaver{outcomes: make(map[string]error)}
+	missing := "https://www.xgstat.com/competitions/premier-league/2025-2026/matches/missing-2026-01-01/advanced-analysis/shot-maps"
+
+	jobs.RunBatch(context.Background(), service, saver, []string{parserCases[0].url, missing}, false, 1)
+
+	if err, ok := saver.outcomes[parserCases[0].url]; !ok || err != nil {
+		t.Errorf("outcome for %s = %v (recorded %v), want success", parserCases[0].url, err, ok)
+	}
+	if err := saver.outcomes[missing]; err == nil {
+		t.Errorf("outcome for %s = %v, want a scrape error", missing, err)
+	}
+}
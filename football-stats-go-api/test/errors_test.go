@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"example/hello/internal/domain"
+	"example/hello/internal/scraper"
+)
+
+func TestScrapeErrorClasses(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		want     error
+		attempts int32
+	}{
+		{"not found", http.StatusNotFound, "", scraper.ErrNotFound, 1},
+		{"rate limited", http.StatusTooManyRequests, "", scraper.ErrBlocked, 3},
+		{"bot check", http.StatusOK, "<html><head><title>Just a moment...</title></head></html>", scraper.ErrBlocked, 3},
+		{"unavailable", http.StatusServiceUnavailable, "", scraper.ErrTimeout, 3},
+		{"no shot map", http.StatusOK, "<html><body><h1>Match</h1></body></html>", scraper.ErrParse, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			service := scraper.NewServiceWithFetcher(scraper.NewHTTPFetcher(time.Second), time.Second, false)
+			service.SetProviders(scraper.NewRegistry(localProvider{server.URL}))
+			service.SetRetryPolicy(scraper.RetryPolicy{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+
+			_, _, err := service.ScrapeXGStatFixture(context.Background(), server.URL+"/match")
+			if !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+			if got := requests.Load(); got != tt.attempts {
+				t.Errorf("made %d requests, want %d", got, tt.attempts)
+			}
+		})
+	}
+}
+
+// localProvider parses pages of a test server as xgstat.com pages
+type localProvider struct {
+	baseURL string
+}
+
+func (p localProvider) Name() string { return "local" }
+
+func (p localProvider) MatchURL(url string) bool { return strings.HasPrefix(url, p.baseURL+"/") }
+
+func (p localProvider) Fetch(ctx context.Context, fetcher scraper.Fetcher, url string) (string, error) {
+	return fetcher.Fetch(ctx, url)
+}
+
+func (p localProvider) Parse(pageData, url string) (*domain.DBXGStatFixture, *scraper.ParseReport, error) {
+	return scraper.XGStatProvider{}.Parse(pageData, url)
+}
+
+// flakyFetcher times out a number of times before serving a saved page
+type flakyFetcher struct {
+	failures atomic.Int32
+}
+
+func (f *flakyFetcher) Fetch(ctx context.Context, url string) (string, error) {
+	if f.failures.Add(-1) >= 0 {
+		return "", context.DeadlineExceeded
+	}
+	return savedPageFetcher{}.Fetch(ctx, url)
+}
+
+func TestScrapeRetriesTimeouts(t *testing.T) {
+	fetcher := &flakyFetcher{}
+	fetcher.failures.Store(2)
+	service := scraper.NewServiceWithFetcher(fetcher, time.Second, false)
+	service.SetRetryPolicy(scraper.RetryPolicy{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+
+	fixture, _, err := service.ScrapeXGStatFixture(context.Background(), parserCases[0].url)
+	if err != nil {
+		t.Fatalf("scrape failed after retries: %v", err)
+	}
+	if fixture.HomeTeam != "Arsenal" {
+		t.Errorf("home team = %q, want Arsenal", fixture.HomeTeam)
+	}
+
+	fetcher.failures.Store(3)
+	_, _, err = service.ScrapeXGStatFixture(context.Background(), parserCases[0].url)
+	if !errors.Is(err, scraper.ErrTimeout) {
+		t.Errorf("error = %v, want ErrTimeout once attempts run out", err)
+	}
+}
+
+func TestScrapeCancelledDuringBackoff(t *testing.T) {
+	fetcher := &flakyFetcher{}
+	fetcher.failures.Store(10)
+	service := scraper.NewServiceWithFetcher(fetcher, time.Second, false)
+	service.SetRetryPolicy(scraper.RetryPolicy{Attempts: 3, BaseDelay: time.Minute, MaxDelay: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, _, err := service.ScrapeXGStatFixture(ctx, parserCases[0].url)
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, scraper.ErrTimeout) {
+		t.Errorf("error = %v, want the context error rather than the last attempt's ErrTimeout", err)
+	}
+	if kind := scraper.ErrorKind(err); kind != "" {
+		t.Errorf("error kind = %q, want none for a cancelled scrape", kind)
+	}
+}
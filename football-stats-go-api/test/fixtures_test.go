@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"example/hello/internal/api"
+	"example/hello/internal/database"
+	"example/hello/internal/domain"
+)
+
+// listedFixtures are saved for the list tests. Two matches share a kick-off
+// and two a total xG, so paging has to break ties.
+var listedFixtures = []domain.DBXGStatFixture{
+	{Source: "xgstat", ID: 1, Gameweek: 1, Date: kickoff("2025-08-16 14:00"), HomeTeam: "Arsenal", AwayTeam: "Chelsea", HomeScore: 2, AwayScore: 1, HomeXG: 1.1, AwayXG: 2.2},
+	{Source: "xgstat", ID: 2, Gameweek: 1, Date: kickoff("2025-08-16 14:00"), HomeTeam: "Everton", AwayTeam: "Fulham", HomeScore: 0, AwayScore: 0, HomeXG: 0.4, AwayXG: 0.35},
+	{Source: "xgstat", ID: 3, Gameweek: 2, Date: kickoff("2025-08-23 16:30"), HomeTeam: "Chelsea", AwayTeam: "Everton", HomeScore: 1, AwayScore: 3, HomeXG: 1.5, AwayXG: 1.8},
+	{Source: "xgstat", ID: 4, Gameweek: 2, Date: kickoff("2025-08-24 12:00"), HomeTeam: "Fulham", AwayTeam: "Arsenal", HomeScore: 1, AwayScore: 1, HomeXG: 0.9, AwayXG: 1.3},
+	{Source: "understat", ID: 4, Gameweek: 2, Date: kickoff("2025-08-24 12:00"), HomeTeam: "Fulham", AwayTeam: "Arsenal", HomeScore: 1, AwayScore: 1, HomeXG: 1.2, AwayXG: 1.0},
+	{Source: "xgstat", ID: 5, Gameweek: 3, Date: kickoff("2025-08-30 19:45"), HomeTeam: "Arsenal", AwayTeam: "Everton", HomeScore: 0, AwayScore: 2, HomeXG: 2.4, AwayXG: 0.6},
+}
+
+func kickoff(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// listedIDs names the fixtures of a page as source/id
+func listedIDs(page *domain.FixturePage) []string {
+	ids := []string{}
+	for _, f := range page.Fixtures {
+		ids = append(ids, f.Source+"/"+strconv.Itoa(f.ID))
+	}
+	return ids
+}
+
+func TestListFixtures(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter domain.FixtureFilter
+		want   []string
+	}{
+		{"newest first", domain.FixtureFilter{}, []string{"xgstat/5", "xgstat/4", "understat/4", "xgstat/3", "xgstat/2", "xgstat/1"}},
+		{"source", domain.FixtureFilter{Source: "understat"}, []string{"understat/4"}},
+		{"gameweek", domain.FixtureFilter{Gameweek: 1, Sort: "date"}, []string{"xgstat/1", "xgstat/2"}},
+		{"team ignores case", domain.FixtureFilter{Team: "arsenal", Source: "xgstat", Sort: "date"}, []string{"xgstat/1", "xgstat/4", "xgstat/5"}},
+		{"date range", domain.FixtureFilter{From: kickoff("2025-08-23 00:00"), Until: kickoff("2025-08-24 12:00"), Sort: "date"}, []string{"xgstat/3"}},
+		{"xg range", domain.FixtureFilter{MinXG: 2.2, MaxXG: 2.9, Sort: "xg"}, []string{"understat/4", "xgstat/4"}},
+		{"draws", domain.FixtureFilter{Result: domain.ResultDraw, Source: "xgstat", Sort: "date"}, []string{"xgstat/2", "xgstat/4"}},
+		{"away wins", domain.FixtureFilter{Result: domain.ResultAway, Sort: "date"}, []string{"xgstat/3", "xgstat/5"}},
+		{"team wins", domain.FixtureFilter{Team: "Everton", Result: domain.ResultWin, Sort: "date"}, []string{"xgstat/3", "xgstat/5"}},
+		{"team losses", domain.FixtureFilter{Team: "Chelsea", Result: domain.ResultLoss, Sort: "date"}, []string{"xgstat/1", "xgstat/3"}},
+		{"most xg first", domain.FixtureFilter{Sort: "-xg", Limit: 3}, []string{"xgstat/1", "xgstat/3", "xgstat/5"}},
+		{"gameweek descending", domain.FixtureFilter{Sort: "-gameweek", Limit: 2}, []string{"xgstat/5", "xgstat/4"}},
+	}
+
+	for name, open := range repositories {
+		t.Run(name, func(t *testing.T) {
+			repo := open(t)
+			ctx := context.Background()
+			for i := range listedFixtures {
+				fixture := listedFixtures[i]
+				if err := repo.SaveXGStatFixture(ctx, &fixture); err != nil {
+					t.Fatalf("SaveXGStatFixture returned error: %v", err)
+				}
+			}
+
+			for _, tt := range tests {
+				page, err := repo.ListFixtures(ctx, tt.filter)
+				if err != nil {
+					t.Errorf("%s: ListFixtures returned error: %v", tt.name, err)
+					continue
+				}
+				if got := listedIDs(page); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+				}
+			}
+
+			// Every sort pages through all fixtures exactly once, in order
+			for _, sort := range []string{"date", "-date", "gameweek", "-gameweek", "xg", "-xg"} {
+				all, err := repo.ListFixtures(ctx, domain.FixtureFilter{Sort: sort})
+				if err != nil {
+					t.Fatalf("%s: ListFixtures returned error: %v", sort, err)
+				}
+				filter := domain.FixtureFilter{Sort: sort, Limit: 2}
+				var paged []string
+				for pages := 0; ; pages++ {
+					if pages == len(listedFixtures) {
+						t.Fatalf("%s: paging does not end", sort)
+					}
+					page, err := repo.ListFixtures(ctx, filter)
+					if err != nil {
+						t.Fatalf("%s: page %d returned error: %v", sort, pages+1, err)
+					}
+					paged = append(paged, listedIDs(page)...)
+					if page.NextCursor == "" {
+						break
+					}
+					filter.Cursor = page.NextCursor
+				}
+				if want := listedIDs(all); !reflect.DeepEqual(paged, want) {
+					t.Errorf("%s: pages %v, want %v", sort, paged, want)
+				}
+			}
+
+			page, err := repo.ListFixtures(ctx, domain.FixtureFilter{Sort: "date", Limit: 2})
+			if err != nil || page.NextCursor == "" {
+				t.Fatalf("first page: %v, %v", page, err)
+			}
+			for _, filter := range []domain.FixtureFilter{
+				{Sort: "xg", Cursor: page.NextCursor},
+				{Cursor: "not a cursor"},
+			} {
+				if _, err := repo.ListFixtures(ctx, filter); !errors.Is(err, database.ErrInvalidCursor) {
+					t.Errorf("cursor %q with sort %q: %v, want ErrInvalidCursor", filter.Cursor, filter.Sort, err)
+				}
+			}
+		})
+	}
+}
+
+// TestListFixturesSharedFixtureID pages one fixture at a time through three
+// xgstat matches saved under one fixture ID, as rows saved before match keys
+// can be, that also tie on every sort value
+func TestListFixturesSharedFixtureID(t *testing.T) {
+	for name, open := range repositories {
+		t.Run(name, func(t *testing.T) {
+			repo := open(t)
+			ctx := context.Background()
+			want := []string{}
+			for _, key := range []string{"arsenal-chelsea-2025-08-16", "everton-fulham-2025-08-16", "brentford-wolves-2025-08-16"} {
+				fixture := domain.DBXGStatFixture{Source: "xgstat", ID: 16, MatchKey: key, Gameweek: 1, Date: kickoff("2025-08-16 14:00"), HomeXG: 1, AwayXG: 1}
+				if err := repo.SaveXGStatFixture(ctx, &fixture); err != nil {
+					t.Fatalf("SaveXGStatFixture returned error: %v", err)
+				}
+				want = append(want, key)
+			}
+
+			for _, sort := range []string{"date", "-date", "gameweek", "-gameweek", "xg", "-xg"} {
+				filter := domain.FixtureFilter{Sort: sort, Limit: 1}
+				var keys []string
+				for pages := 0; ; pages++ {
+					if pages == len(want) {
+						t.Fatalf("%s: paging does not end", sort)
+					}
+					page, err := repo.ListFixtures(ctx, filter)
+					if err != nil {
+						t.Fatalf("%s: page %d returned error: %v", sort, pages+1, err)
+					}
+					for _, f := range page.Fixtures {
+						keys = append(keys, f.MatchKey)
+					}
+					if page.NextCursor == "" {
+						break
+					}
+					filter.Cursor = page.NextCursor
+				}
+				if !reflect.DeepEqual(sorted(keys), sorted(want)) {
+					t.Errorf("%s: pages %v, want each of %v once", sort, keys, want)
+				}
+			}
+		})
+	}
+}
+
+// sorted returns a sorted copy of keys
+func sorted(keys []string) []string {
+	return slices.Sorted(slices.Values(keys))
+}
+
+func TestListFixturesHandler(t *testing.T) {
+	repo := database.NewMemory()
+	for i := range listedFixtures {
+		fixture := listedFixtures[i]
+		if err := repo.SaveXGStatFixture(context.Background(), &fixture); err != nil {
+			t.Fatalf("SaveXGStatFixture returned error: %v", err)
+		}
+	}
+	handler := api.NewHandler(nil, nil, repo, nil)
+
+	list := func(target string) (int, *domain.FixturePage) {
+		rec := httptest.NewRecorder()
+		handler.ListFixtures(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		var resp struct {
+			Data *domain.FixturePage `json:"data"`
+		}
+		json.NewDecoder(rec.Body).Decode(&resp)
+		return rec.Code, resp.Data
+	}
+
+	code, page := list("/api/fixtures?team=Arsenal&from=2025-08-16&to=2025-08-24&source=xgstat&sort=date&limit=1")
+	if code != http.StatusOK || page == nil || len(page.Fixtures) != 1 || page.Fixtures[0].ID != 1 || page.NextCursor == "" {
+		t.Fatalf("first page: status %d, %+v", code, page)
+	}
+	// to includes the whole day, so the match on 24 August is listed
+	code, page = list("/api/fixtures?team=Arsenal&from=2025-08-16&to=2025-08-24&source=xgstat&sort=date&limit=1&cursor=" + page.NextCursor)
+	if code != http.StatusOK || page == nil || len(page.Fixtures) != 1 || page.Fixtures[0].ID != 4 || page.NextCursor != "" {
+		t.Fatalf("second page: status %d, %+v", code, page)
+	}
+
+	// Without a source only xgstat's copies are listed
+	for target, want := range map[string][]string{
+		"/api/fixtures?team=Arsenal&sort=date":                  {"xgstat/1", "xgstat/4", "xgstat/5"},
+		"/api/fixtures?team=Arsenal&sort=date&source=understat": {"understat/4"},
+	} {
+		if code, page := list(target); code != http.StatusOK || page == nil || !reflect.DeepEqual(listedIDs(page), want) {
+			t.Errorf("%s: status %d, %+v, want %v", target, code, page, want)
+		}
+	}
+
+	for _, target := range []string{
+		"/api/fixtures?result=win",
+		"/api/fixtures?result=lost",
+		"/api/fixtures?sort=team",
+		"/api/fixtures?from=16-08-2025",
+		"/api/fixtures?min_xg=lots",
+		"/api/fixtures?limit=-1",
+		"/api/fixtures?cursor=abc",
+	} {
+		if code, _ := list(target); code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", target, code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	api.NewHandler(nil, nil, nil, nil).ListFixtures(rec, httptest.NewRequest(http.MethodGet, "/api/fixtures", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("no database: status %d, want 503", rec.Code)
+	}
+}
+
+// TestUnknownSource rejects a source no fixtures are stored under instead of
+// answering with an empty result
+func TestUnknownSource(t *testing.T) {
+	handler := api.NewHandler(nil, nil, database.NewMemory(), nil)
+	endpoints := []struct {
+		target string
+		serve  http.HandlerFunc
+	}{
+		{"/api/xgstats?id=1", handler.GetXGStatFixture},
+		{"/api/fixtures", handler.ListFixtures},
+		{"/api/teams/Arsenal/summary", handler.GetTeamSummary},
+		{"/api/players/Bukayo%20Saka/shots", handler.GetPlayerShots},
+		{"/api/players/Bukayo%20Saka/summary", handler.GetPlayerSummary},
+		{"/api/table", handler.GetLeagueTable},
+	}
+
+	for _, endpoint := range endpoints {
+		for source, want := range map[string]int{"fbref": http.StatusBadRequest, "XGSTAT": http.StatusBadRequest, "understat": 0} {
+			target := endpoint.target + "?source=" + source
+			if strings.Contains(endpoint.target, "?") {
+				target = endpoint.target + "&source=" + source
+			}
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			req.SetPathValue("team", "Arsenal")
+			req.SetPathValue("name", "Bukayo Saka")
+			rec := httptest.NewRecorder()
+			endpoint.serve(rec, req)
+
+			if want != 0 && rec.Code != want {
+				t.Errorf("%s: status %d, want %d", target, rec.Code, want)
+			}
+			if want == 0 && rec.Code == http.StatusBadRequest {
+				t.Errorf("%s: status 400 for a known source: %s", target, rec.Body)
+			}
+		}
+	}
+}
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"example/hello/internal/api"
+	"example/hello/internal/domain"
+	"example/hello/internal/jobs"
+	"example/hello/internal/scraper"
+)
+
+// savedPageFetcher serves the saved parser pages by their original URL
+type savedPageFetcher struct{}
+
+func (savedPageFetcher) Fetch(ctx context.Context, url string) (string, error) {
+	for _, tc := range parserCases {
+		if tc.url == url {
+			page, err := os.ReadFile(filepath.Join("testdata", "xgstat", tc.name+".html"))
+			return string(page), err
+		}
+	}
+	return "", fmt.Errorf("%w: status 404", scraper.ErrNotFound)
+}
+
+func newTestQueue(t *testing.T, store jobs.Store) *jobs.Queue {
+	t.Helper()
+	service := scraper.NewServiceWithFetcher(savedPageFetcher{}, time.Second, false)
+	queue := jobs.NewQueue(store, service, nil, jobs.Options{Workers: 2, QueueSize: 4})
+	if err := queue.Start(context.Background()); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	t.Cleanup(queue.Stop)
+	return queue
+}
+
+// waitForJob polls the queue until the job has finished
+func waitForJob(t *testing.T, queue *jobs.Queue, id string) *jobs.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := queue.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Get returned error: %v", err)
+		}
+		if job.Finished() {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return nil
+}
+
+func TestQueueRunsJob(t *testing.T) {
+	queue := newTestQueue(t, jobs.NewMemoryStore())
+
+	job, err := queue.Submit(context.Background(), parserCases[0].url, true)
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	if job.Status != jobs.StatusQueued {
+		t.Errorf("submitted job status = %q, want %q", job.Status, jobs.StatusQueued)
+	}
+
+	done := waitForJob(t, queue, job.ID)
+	if done.Status != jobs.StatusSucceeded {
+		t.Fatalf("status = %q (error %q), want %q", done.Status, done.Error, jobs.StatusSucceeded)
+	}
+	if done.Fixture == nil || done.Fixture.HomeTeam != "Arsenal" {
+		t.Errorf("fixture = %+v, want the Arsenal fixture", done.Fixture)
+	}
+	if done.Report == nil || !done.Report.Complete {
+		t.Errorf("report = %+v, want a complete report", done.Report)
+	}
+	if done.StartedAt == nil || done.FinishedAt == nil {
+		t.Error("expected start and finish times to be recorded")
+	}
+}
+
+func TestQueueRecordsFailure(t *testing.T) {
+	queue := newTestQueue(t, jobs.NewMemoryStore())
+
+	job, err := queue.Submit(context.Background(), "https://www.xgstat.com/competitions/premier-league/2025-2026/matches/missing-2026-01-01/advanced-analysis/shot-maps", false)
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+
+	done := waitForJob(t, queue, job.ID)
+	if done.Status != jobs.StatusFailed || done.Error == "" {
+		t.Errorf("got status %q with error %q, want a failed job with an error", done.Status, done.Error)
+	}
+	if done.ErrorType != "not_found" {
+		t.Errorf("error type = %q, want not_found", done.ErrorType)
+	}
+	if done.Fixture != nil {
+		t.Error("failed job should not carry a fixture")
+	}
+}
+
+func TestQueueResumesUnfinishedJobs(t *testing.T) {
+	store := jobs.NewMemoryStore()
+	interrupted := &jobs.Job{
+		ID:        "interrupted",
+		URL:       parserCases[1].url,
+		Status:    jobs.StatusRunning,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := store.CreateJob(context.Background(), interrupted); err != nil {
+		t.Fatalf("CreateJob returned error: %v", err)
+	}
+
+	queue := newTestQueue(t, store)
+
+	done := waitForJob(t, queue, interrupted.ID)
+	if done.Status != jobs.StatusSucceeded {
+		t.Errorf("status = %q (error %q), want %q", done.Status, done.Error, jobs.StatusSucceeded)
+	}
+}
+
+func TestQueueFailsInterruptedBatchJobs(t *testing.T) {
+	store := jobs.NewMemoryStore()
+	interrupted := &jobs.Job{
+		ID:        "interrupted",
+		URL:       scraper.GameweekListingURL("premier-league", "2025-2026", 23),
+		Status:    jobs.StatusRunning,
+		Batch:     true,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := store.CreateJob(context.Background(), interrupted); err != nil {
+		t.Fatalf("CreateJob returned error: %v", err)
+	}
+
+	queue := newTestQueue(t, store)
+
+	done := waitForJob(t, queue, interrupted.ID)
+	if done.Status != jobs.StatusFailed || done.Error != jobs.ErrInterrupted.Error() {
+		t.Errorf("got status %q with error %q, want the batch job failed as interrupted", done.Status, done.Error)
+	}
+}
+
+func TestQueueGetUnknownJob(t *testing.T) {
+	queue := newTestQueue(t, jobs.NewMemoryStore())
+
+	if _, err := queue.Get(context.Background(), "missing"); err != jobs.ErrNotFound {
+		t.Errorf("Get error = %v, want %v", err, jobs.ErrNotFound)
+	}
+}
+
+// TestGetScrapeJobFailureStatus answers a failed job with the HTTP status of
+// its error class and the job, report included, in data
+func TestGetScrapeJobFailureStatus(t *testing.T) {
+	store := jobs.NewMemoryStore()
+	handler := api.NewHandler(nil, nil, nil, jobs.NewQueue(store, nil, nil, jobs.Options{}))
+
+	get := func(id string) (int, api.Response) {
+		req := httptest.NewRequest(http.MethodGet, "/api/scrape/jobs/"+id, nil)
+		req.SetPathValue("id", id)
+		rec := httptest.NewRecorder()
+		handler.GetScrapeJob(rec, req)
+
+		var resp api.Response
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", id, err)
+		}
+		return rec.Code, resp
+	}
+
+	tests := []struct {
+		errorType string
+		batch     bool
+		want      int
+	}{
+		{"not_found", false, http.StatusNotFound},
+		{"disallowed", false, http.StatusForbidden},
+		{"blocked", false, http.StatusServiceUnavailable},
+		{"timeout", false, http.StatusGatewayTimeout},
+		{"parse", false, http.StatusUnprocessableEntity},
+		{"incomplete", false, http.StatusUnprocessableEntity},
+		{"unsupported", false, http.StatusBadRequest},
+		{"", false, http.StatusBadGateway},
+		// a gameweek whose listing could not be loaded
+		{"blocked", true, http.StatusServiceUnavailable},
+	}
+
+	for i, tt := range tests {
+		job := &jobs.Job{
+			ID:        fmt.Sprintf("failed-%d", i),
+			URL:       parserCases[0].url,
+			Status:    jobs.StatusFailed,
+			Batch:     tt.batch,
+			Report:    &scraper.ParseReport{URL: parserCases[0].url},
+			Error:     "scrape failed",
+			ErrorType: tt.errorType,
+		}
+		if err := store.CreateJob(context.Background(), job); err != nil {
+			t.Fatalf("CreateJob returned error: %v", err)
+		}
+
+		code, resp := get(job.ID)
+		if code != tt.want || resp.Success || resp.Error != job.Error {
+			t.Errorf("%q (batch %v): status %d, success %v, error %q; want %d", tt.errorType, tt.batch, code, resp.Success, resp.Error, tt.want)
+		}
+		data, _ := resp.Data.(map[string]interface{})
+		if data["id"] != job.ID || data["error_type"] != nilIfEmpty(tt.errorType) || data["report"] == nil {
+			t.Errorf("%q: data = %v, want the job with its report", tt.errorType, resp.Data)
+		}
+	}
+
+	done := &jobs.Job{ID: "done", URL: parserCases[0].url, Status: jobs.StatusSucceeded}
+	if err := store.CreateJob(context.Background(), done); err != nil {
+		t.Fatalf("CreateJob returned error: %v", err)
+	}
+	if code, resp := get(done.ID); code != http.StatusOK || !resp.Success {
+		t.Errorf("succeeded job: status %d, success %v, want 200", code, resp.Success)
+	}
+	if code, resp := get("missing"); code != http.StatusNotFound || resp.Data != nil {
+		t.Errorf("unknown job: status %d, data %v, want 404 without data", code, resp.Data)
+	}
+}
+
+// incompletePageFetcher serves the first saved page without its gameweek
+type incompletePageFetcher struct{}
+
+func (incompletePageFetcher) Fetch(ctx context.Context, url string) (string, error) {
+	page, err := savedPageFetcher{}.Fetch(ctx, url)
+	return strings.Replace(page, ">GW23<", "><", 1), err
+}
+
+// TestStrictJobRejectedAsIncomplete fails a strict job with an incomplete
+// report as "incomplete", which its status request answers with 422 and the report
+func TestStrictJobRejectedAsIncomplete(t *testing.T) {
+	service := scraper.NewServiceWithFetcher(incompletePageFetcher{}, time.Second, false)
+	saver := &recordingSaver{}
+	queue := jobs.NewQueue(jobs.NewMemoryStore(), service, saver, jobs.Options{Workers: 1, QueueSize: 4})
+	if err := queue.Start(context.Background()); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	t.Cleanup(queue.Stop)
+
+	job, err := queue.Submit(context.Background(), parserCases[0].url, true)
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	done := waitForJob(t, queue, job.ID)
+	if done.Status != jobs.StatusFailed || done.ErrorType != "incomplete" || done.Error != scraper.ErrIncomplete.Error() {
+		t.Errorf("got status %q, error type %q, error %q; want failed as incomplete", done.Status, done.ErrorType, done.Error)
+	}
+	if len(saver.saved) != 0 {
+		t.Errorf("saved %v, want nothing saved", saver.saved)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/scrape/jobs/"+job.ID, nil)
+	req.SetPathValue("id", job.ID)
+	rec := httptest.NewRecorder()
+	api.NewHandler(nil, nil, nil, queue).GetScrapeJob(rec, req)
+
+	var resp struct {
+		Success bool     `json:"success"`
+		Data    jobs.Job `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if rec.Code != http.StatusUnprocessableEntity || resp.Success {
+		t.Errorf("status %d, success %v, want 422", rec.Code, resp.Success)
+	}
+	if resp.Data.Report == nil || len(resp.Data.Report.FieldsMissing) != 1 || resp.Data.Report.FieldsMissing[0] != "gameweek" {
+		t.Errorf("report = %+v, want gameweek missing", resp.Data.Report)
+	}
+}
+
+// nilIfEmpty is how an omitempty string decodes into a generic map
+func nilIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// recordingSaver remembers the fixtures it was asked to save
+type recordingSaver struct {
+	mu    sync.Mutex
+	saved []int
+}
+
+func (s *recordingSaver) SaveXGStatFixture(ctx context.Context, fixture *domain.DBXGStatFixture) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saved = append(s.saved, fixture.ID)
+	return nil
+}
+
+func TestRunBatchContinuesPastFailures(t *testing.T) {
+	service := scraper.NewServiceWithFetcher(savedPageFetcher{}, time.Second, false)
+	saver := &recordingSaver{}
+	urls := []string{
+		parserCases[0].url,
+		"https://www.xgstat.com/competitions/premier-league/2025-2026/matches/missing-2026-01-01/advanced-analysis/shot-maps",
+		parserCases[1].url,
+	}
+
+	results := jobs.RunBatch(context.Background(), service, saver, urls, false, 2)
+
+	if len(results) != len(urls) {
+		t.Fatalf("got %d results, want %d", len(results), len(urls))
+	}
+	wantStatus := []jobs.Status{jobs.StatusSucceeded, jobs.StatusFailed, jobs.StatusSucceeded}
+	for i, result := range results {
+		if result.URL != urls[i] {
+			t.Errorf("result %d is for %s, want %s", i, result.URL, urls[i])
+		}
+		if result.Status != wantStatus[i] {
+			t.Errorf("result %d status = %q (error %q), want %q", i, result.Status, result.Error, wantStatus[i])
+		}
+	}
+	if len(saver.saved) != 2 {
+		t.Errorf("saved %d fixtures, want 2", len(saver.saved))
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"example/hello/internal/scraper"
+)
+
+const gameweekListing = `<html><body>
+<main>
+  <a href="/competitions/premier-league/2025-2026">Premier League</a>
+  <div class="fixture-card">
+    <a href="/competitions/premier-league/2025-2026/matches/arsenal-manchester-united-2026-01-24">Arsenal 2 - 1 Manchester United</a>
+    <a href="/competitions/premier-league/2025-2026/matches/arsenal-manchester-united-2026-01-24/advanced-analysis/shot-maps">Shot maps</a>
+  </div>
+  <div class="fixture-card">
+    <a href="https://www.xgstat.com/competitions/premier-league/2025-2026/matches/liverpool-chelsea-2026-01-25?tab=overview">Liverpool 1 - 1 Chelsea</a>
+  </div>
+  <aside>
+    <a href="/competitions/premier-league/2024-2025/matches/chelsea-arsenal-2025-01-25">Last season</a>
+    <a href="/competitions/championship/2025-2026/matches/leeds-burnley-2026-01-24">Championship</a>
+  </aside>
+</main>
+</body></html>`
+
+func TestParseMatchURLs(t *testing.T) {
+	urls, err := scraper.ParseMatchURLs(gameweekListing, "premier-league", "2025-2026")
+	if err != nil {
+		t.Fatalf("ParseMatchURLs returned error: %v", err)
+	}
+
+	want := []string{
+		"https://www.xgstat.com/competitions/premier-league/2025-2026/matches/arsenal-manchester-united-2026-01-24/advanced-analysis/shot-maps",
+		"https://www.xgstat.com/competitions/premier-league/2025-2026/matches/liverpool-chelsea-2026-01-25/advanced-analysis/shot-maps",
+	}
+	if !reflect.DeepEqual(urls, want) {
+		t.Errorf("ParseMatchURLs = %v, want %v", urls, want)
+	}
+}
+
+func TestGameweekListingURL(t *testing.T) {
+	got := scraper.GameweekListingURL("premier-league", "2025-2026", 23)
+	want := "https://www.xgstat.com/competitions/premier-league/2025-2026/matches?gameweek=23"
+	if got != want {
+		t.Errorf("GameweekListingURL = %q, want %q", got, want)
+	}
+}
+
+// TestXGStatMatchID checks that matches played on the same day of the month
+// get different IDs, and that a sub-page of a match keeps the match's ID
+func TestXGStatMatchID(t *testing.T) {
+	arsenal := scraper.ShotMapURL("premier-league", "2025-2026", "arsenal-manchester-united-2026-01-24")
+	liverpool := scraper.ShotMapURL("premier-league", "2025-2026", "liverpool-chelsea-2025-10-24")
+
+	if id := scraper.XGStatMatchID(arsenal); id == 0 || id == 24 {
+		t.Errorf("XGStatMatchID(%q) = %d, want an ID derived from the slug", arsenal, id)
+	}
+	if scraper.XGStatMatchID(arsenal) == scraper.XGStatMatchID(liverpool) {
+		t.Errorf("matches on the 24th share ID %d", scraper.XGStatMatchID(arsenal))
+	}
+	overview := "https://www.xgstat.com/competitions/premier-league/2025-2026/matches/arsenal-manchester-united-2026-01-24?tab=overview"
+	if scraper.XGStatMatchID(overview) != scraper.XGStatMatchID(arsenal) {
+		t.Error("the overview and shot map pages of a match have different IDs")
+	}
+	if id := scraper.XGStatMatchID("https://understat.com/match/26631"); id != 0 {
+		t.Errorf("XGStatMatchID of an Understat URL = %d, want 0", id)
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"example/hello/internal/config"
+	"example/hello/internal/database"
+	"example/hello/internal/domain"
+	"example/hello/internal/scraper"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// sameDayURLs are two xgstat.com matches whose slugs end in the same day of the month
+var sameDayURLs = []string{
+	scraper.ShotMapURL("premier-league", "2025-2026", "arsenal-manchester-united-2026-01-24"),
+	scraper.ShotMapURL("premier-league", "2025-2026", "liverpool-chelsea-2025-10-24"),
+}
+
+// sameDayFixtures parses the saved Arsenal page once for each of sameDayURLs
+func sameDayFixtures(t *testing.T) []*domain.DBXGStatFixture {
+	t.Helper()
+	page, err := os.ReadFile(filepath.Join("testdata", "xgstat", parserCases[0].name+".html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+
+	var fixtures []*domain.DBXGStatFixture
+	for _, url := range sameDayURLs {
+		fixture, err := scraper.ParseXGStatData(string(page), url)
+		if err != nil {
+			t.Fatalf("ParseXGStatData(%s) returned error: %v", url, err)
+		}
+		fixtures = append(fixtures, fixture)
+	}
+	return fixtures
+}
+
+// openMigrations returns a migrate instance for the Postgres database in
+// TEST_DATABASE_URL, skipping the test when it is not set. The database is
+// emptied first, so it must be a scratch database.
+func openMigrations(t *testing.T) (*migrate.Migrate, string) {
+	t.Helper()
+	dbURL := os.Getenv("TEST_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	m, err := migrate.New("file://../migrations", dbURL)
+	if err != nil {
+		t.Fatalf("failed to create migrate instance: %v", err)
+	}
+	if err := m.Drop(); err != nil {
+		t.Fatalf("failed to empty the database: %v", err)
+	}
+	m.Close()
+
+	// Drop also removes the version table, so migrations start from a new instance
+	m, err = migrate.New("file://../migrations", dbURL)
+	if err != nil {
+		t.Fatalf("failed to create migrate instance: %v", err)
+	}
+	t.Cleanup(func() { m.Close() })
+	return m, dbURL
+}
+
+// recover000009 applies 000009 with the gameweek in the fixture key, as
+// MIGRATIONS.md describes for databases the migration fails on
+const recover000009 = `
+	ALTER TABLE xgstat_fixtures
+		ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'xgstat';
+	ALTER TABLE xgstat_fixtures
+		DROP CONSTRAINT IF EXISTS xgstat_fixtures_fixture_id_gameweek_key,
+		ADD CONSTRAINT xgstat_fixtures_source_fixture_id_gameweek_key UNIQUE (source, fixture_id, gameweek);
+	ALTER TABLE xgstat_shots
+		ADD COLUMN IF NOT EXISTS situation VARCHAR(30);
+`
+
+// TestMigrationsKeepSameDayMatches upgrades a database holding two xgstat
+// matches that both had 24, their day of the month, as fixture ID, then saves
+// matches on the 24th through the repository and rolls 000011 back
+func TestMigrationsKeepSameDayMatches(t *testing.T) {
+	m, dbURL := openMigrations(t)
+	if err := m.Migrate(8); err != nil {
+		t.Fatalf("migrating to version 8: %v", err)
+	}
+
+	db, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+		INSERT INTO xgstat_fixtures (
+			gameweek, fixture_id, fixture_date, home_team, away_team,
+			home_score, away_score, home_xg, away_xg
+		) VALUES
+			(23, 24, '2026-01-24 17:30', 'Arsenal', 'Manchester United', 2, 1, 2.34, 1.12),
+			(9, 24, '2025-10-24 19:00', 'Liverpool', 'Chelsea', 1, 1, 1.50, 1.10)
+	`)
+	if err != nil {
+		t.Fatalf("failed to insert legacy fixtures: %v", err)
+	}
+
+	// 000009 cannot key the two matches on their ID and is recovered by hand
+	if err := m.Migrate(9); err == nil {
+		t.Fatal("migrating to version 9 succeeded with two fixtures sharing an ID")
+	}
+	if _, err := db.Exec(recover000009); err != nil {
+		t.Fatalf("failed to apply 000009 by hand: %v", err)
+	}
+	if err := m.Force(9); err != nil {
+		t.Fatalf("forcing version 9: %v", err)
+	}
+	if err := m.Up(); err != nil {
+		t.Fatalf("migrating up: %v", err)
+	}
+
+	keys := func() []string {
+		rows, err := db.Query(`SELECT match_key FROM xgstat_fixtures ORDER BY match_key`)
+		if err != nil {
+			t.Fatalf("failed to query match keys: %v", err)
+		}
+		defer rows.Close()
+		var keys []string
+		for rows.Next() {
+			var key string
+			if err := rows.Scan(&key); err != nil {
+				t.Fatalf("failed to scan match key: %v", err)
+			}
+			keys = append(keys, key)
+		}
+		return keys
+	}
+	want := []string{"arsenal-manchester-united-2026-01-24", "liverpool-chelsea-2025-10-24"}
+	if got := keys(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("match keys after migrating = %v, want %v", got, want)
+	}
+
+	cfg := &config.Config{Database: config.DatabaseConfig{URL: dbURL, MaxOpenConns: 2, QueryTimeout: 5 * time.Second}}
+	store, err := database.NewService(cfg)
+	if err != nil {
+		t.Fatalf("NewService returned error: %v", err)
+	}
+	defer store.Close()
+
+	// Saving the matches again updates the migrated rows instead of adding new ones
+	ctx := context.Background()
+	for _, fixture := range sameDayFixtures(t) {
+		if err := store.SaveXGStatFixture(ctx, fixture); err != nil {
+			t.Fatalf("SaveXGStatFixture(%s) returned error: %v", fixture.MatchKey, err)
+		}
+		got, err := store.GetFixtureByID(ctx, domain.SourceXGStat, fixture.ID)
+		if err != nil {
+			t.Fatalf("GetFixtureByID(%d) returned error: %v", fixture.ID, err)
+		}
+		if got.MatchKey != fixture.MatchKey {
+			t.Errorf("GetFixtureByID(%d) returned match %q, want %q", fixture.ID, got.MatchKey, fixture.MatchKey)
+		}
+	}
+	if got := keys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("match keys after saving = %v, want %v", got, want)
+	}
+
+	// Two matches sharing an ID keep only the newest when 000011 is rolled back
+	_, err = db.Exec(`
+		INSERT INTO xgstat_fixtures (
+			source, match_key, gameweek, fixture_id, fixture_date, home_team, away_team,
+			home_score, away_score, home_xg, away_xg, updated_at
+		) VALUES
+			('understat', '1', 1, 1, '2025-08-16 15:00', 'Everton', 'Fulham', 0, 0, 0.8, 0.9, '2025-08-17'),
+			('understat', '1-old', 1, 1, '2025-08-16 15:00', 'Everton', 'Fulham', 0, 0, 0.7, 0.9, '2025-08-16')
+	`)
+	if err != nil {
+		t.Fatalf("failed to insert fixtures sharing an ID: %v", err)
+	}
+	if err := m.Migrate(10); err != nil {
+		t.Fatalf("migrating down to version 10: %v", err)
+	}
+	var homeXG float64
+	if err := db.QueryRow(`SELECT home_xg FROM xgstat_fixtures WHERE source = 'understat' AND fixture_id = 1`).Scan(&homeXG); err != nil {
+		t.Fatalf("failed to query the kept fixture: %v", err)
+	}
+	if homeXG != 0.8 {
+		t.Errorf("kept fixture with home xG %.1f, want the newest (0.8)", homeXG)
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"example/hello/internal/domain"
+	"example/hello/internal/scraper"
+)
+
+// Run `go test ./test -update` to rewrite the golden files after an intended parser change
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// parserCases lists the xgstat.com test pages under testdata/xgstat. They are
+// hand-written reconstructions of the live markup, not captures; see the
+// README there. Each page <name>.html has its expected fixture in <name>.golden.json.
+var parserCases = []struct {
+	name string
+	url  string
+}{
+	{
+		name: "arsenal-manchester-united-2026-01-24",
+		url:  "https://www.xgstat.com/competitions/premier-league/2025-2026/matches/arsenal-manchester-united-2026-01-24/advanced-analysis/shot-maps",
+	},
+	{
+		name: "nottingham-forest-wolves-2025-09-20",
+		url:  "https://www.xgstat.com/competitions/premier-league/2025-2026/matches/nottingham-forest-wolves-2025-09-20/advanced-analysis/shot-maps",
+	},
+}
+
+// fieldChecks pick out the parts of a fixture that are compared one by one,
+// so a markup change on xgstat.com reports exactly which field broke.
+var fieldChecks = []struct {
+	name  string
+	value func(f *domain.DBXGStatFixture) interface{}
+}{
+	{"source", func(f *domain.DBXGStatFixture) interface{} { return f.Source }},
+	{"id", func(f *domain.DBXGStatFixture) interface{} { return f.ID }},
+	{"match_key", func(f *domain.DBXGStatFixture) interface{} { return f.MatchKey }},
+	{"teams", func(f *domain.DBXGStatFixture) interface{} { return [2]string{f.HomeTeam, f.AwayTeam} }},
+	{"score", func(f *domain.DBXGStatFixture) interface{} { return [2]int{f.HomeScore, f.AwayScore} }},
+	{"xg", func(f *domain.DBXGStatFixture) interface{} { return [2]float64{f.HomeXG, f.AwayXG} }},
+	{"gameweek", func(f *domain.DBXGStatFixture) interface{} { return f.Gameweek }},
+	{"date", func(f *domain.DBXGStatFixture) interface{} { return f.Date.UTC() }},
+	{"home_shots/off_target", shotsOfType(true, "off_target")},
+	{"home_shots/blocked", shotsOfType(true, "blocked")},
+	{"home_shots/on_target", shotsOfType(true, "on_target")},
+	{"home_shots/goal", shotsOfType(true, "goal")},
+	{"away_shots/off_target", shotsOfType(false, "off_target")},
+	{"away_shots/blocked", shotsOfType(false, "blocked")},
+	{"away_shots/on_target", shotsOfType(false, "on_target")},
+	{"away_shots/goal", shotsOfType(false, "goal")},
+}
+
+func shotsOfType(home bool, shotType string) func(f *domain.DBXGStatFixture) interface{} {
+	return func(f *domain.DBXGStatFixture) interface{} {
+		shots := f.AwayShots
+		if home {
+			shots = f.HomeShots
+		}
+		matched := []domain.DBXGStatShot{}
+		for _, shot := range shots {
+			if shot.ShotType == shotType {
+				matched = append(matched, shot)
+			}
+		}
+		return matched
+	}
+}
+
+func TestParseXGStatDataGolden(t *testing.T) {
+	for _, tc := range parserCases {
+		t.Run(tc.name, func(t *testing.T) {
+			page, err := os.ReadFile(filepath.Join("testdata", "xgstat", tc.name+".html"))
+			if err != nil {
+				t.Fatalf("failed to read page: %v", err)
+			}
+
+			got, report, err := scraper.ParseXGStatReport(string(page), tc.url)
+			if err != nil {
+				t.Fatalf("ParseXGStatReport returned error: %v", err)
+			}
+			if !report.Complete {
+				t.Errorf("parse report incomplete: missing %v, issues %v", report.FieldsMissing, report.Issues)
+			}
+			// xgstat.com markers carry no player, minute or xG, and the report must say so
+			if report.ShotDetail || domain.SourceShotDetail(domain.SourceXGStat) {
+				t.Error("xgstat shots reported as carrying player, minute and xG")
+			}
+
+			goldenPath := filepath.Join("testdata", "xgstat", tc.name+".golden.json")
+			if *update {
+				writeGolden(t, goldenPath, got)
+			}
+
+			want := readGolden(t, goldenPath)
+
+			for _, check := range fieldChecks {
+				gotValue, wantValue := check.value(got), check.value(want)
+				if !reflect.DeepEqual(gotValue, wantValue) {
+					t.Errorf("%s mismatch:\n got: %+v\nwant: %+v", check.name, gotValue, wantValue)
+				}
+			}
+
+			if len(got.HomeShots) != len(want.HomeShots) || len(got.AwayShots) != len(want.AwayShots) {
+				t.Errorf("shot count mismatch: got %d/%d, want %d/%d",
+					len(got.HomeShots), len(got.AwayShots), len(want.HomeShots), len(want.AwayShots))
+			}
+		})
+	}
+}
+
+// TestParseXGStatDataIgnoresMarkupLayout re-parses a saved page after reordering
+// attributes and adding whitespace, which must not change the parsed fixture
+func TestParseXGStatDataIgnoresMarkupLayout(t *testing.T) {
+	tc := parserCases[0]
+	page, err := os.ReadFile(filepath.Join("testdata", "xgstat", tc.name+".html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+
+	want, err := scraper.ParseXGStatData(string(page), tc.url)
+	if err != nil {
+		t.Fatalf("ParseXGStatData returned error: %v", err)
+	}
+
+	reordered := regexp.MustCompile(`<circle r="([^"]*)" cx="([^"]*)" cy="([^"]*)" stroke="([^"]*)" fill="([^"]*)" fill-opacity="([^"]*)">`).
+		ReplaceAllString(string(page), `<circle fill-opacity="$6" data-id="x" fill="$5" cy="$3" stroke="$4" cx="$2" r="$1">`)
+	reordered = strings.ReplaceAll(reordered, "</span><span", "</span>\n    <span")
+	reordered = strings.ReplaceAll(reordered, `class="hidden lg:inline"`, `class="lg:inline  hidden"`)
+
+	got, err := scraper.ParseXGStatData(reordered, tc.url)
+	if err != nil {
+		t.Fatalf("ParseXGStatData returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("reordered markup changed the parsed fixture:\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+// TestParseXGStatDataRequiresKickoff rejects pages whose kick-off date is missing
+func TestParseXGStatDataRequiresKickoff(t *testing.T) {
+	tc := parserCases[0]
+	page, err := os.ReadFile(filepath.Join("testdata", "xgstat", tc.name+".html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+
+	withoutDate := strings.Replace(string(page), `<span class="text-foreground text-nowrap">24 Jan 17:30</span>`, "", 1)
+	if withoutDate == string(page) {
+		t.Fatal("test page no longer contains the expected kick-off span")
+	}
+
+	if _, err := scraper.ParseXGStatData(withoutDate, tc.url); err == nil {
+		t.Error("expected an error for a page without a kick-off date")
+	}
+}
+
+func TestParseXGStatReport(t *testing.T) {
+	tc := parserCases[0]
+	page, err := os.ReadFile(filepath.Join("testdata", "xgstat", tc.name+".html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		mutate       func(string) string
+		wantComplete bool
+		wantMissing  []string
+		wantIssues   int
+		wantGaps     int
+	}{
+		{
+			name:         "complete page",
+			mutate:       func(s string) string { return s },
+			wantComplete: true,
+			wantMissing:  []string{},
+			// per team: no per-shot xG and unattributed shots
+			wantGaps: 4,
+		},
+		{
+			name: "goal marker removed",
+			mutate: func(s string) string {
+				return regexp.MustCompile(`<svg x="31.4"[^\n]*</svg>\n`).ReplaceAllString(s, "")
+			},
+			wantMissing: []string{},
+			wantIssues:  1,
+			wantGaps:    4,
+		},
+		{
+			name: "headline xG differs from player table",
+			mutate: func(s string) string {
+				return strings.Replace(s, `<span class="tabular-nums">2.34</span>`, `<span class="tabular-nums">2.64</span>`, 1)
+			},
+			wantMissing: []string{},
+			wantIssues:  1,
+			wantGaps:    4,
+		},
+		{
+			name: "gameweek and away shot map missing",
+			mutate: func(s string) string {
+				s = strings.Replace(s, ">GW23<", "><", 1)
+				return strings.Replace(s, "Manchester United xG Shot Map", "Shot Map", 1)
+			},
+			wantMissing: []string{"gameweek", "away_shot_map"},
+			wantGaps:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, report, err := scraper.ParseXGStatReport(tt.mutate(string(page)), tc.url)
+			if err != nil {
+				t.Fatalf("ParseXGStatReport returned error: %v", err)
+			}
+
+			if report.Complete != tt.wantComplete {
+				t.Errorf("complete = %v, want %v (issues: %v)", report.Complete, tt.wantComplete, report.Issues)
+			}
+			if !reflect.DeepEqual(report.FieldsMissing, tt.wantMissing) {
+				t.Errorf("missing fields = %v, want %v", report.FieldsMissing, tt.wantMissing)
+			}
+			if len(report.Issues) != tt.wantIssues {
+				t.Errorf("got %d issues, want %d: %v", len(report.Issues), tt.wantIssues, report.Issues)
+			}
+			if len(report.Gaps) != tt.wantGaps {
+				t.Errorf("got %d gaps, want %d: %v", len(report.Gaps), tt.wantGaps, report.Gaps)
+			}
+		})
+	}
+}
+
+func readGolden(t *testing.T, path string) *domain.DBXGStatFixture {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+
+	var fixture domain.DBXGStatFixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatalf("failed to decode golden file: %v", err)
+	}
+	return &fixture
+}
+
+func writeGolden(t *testing.T, path string, fixture *domain.DBXGStatFixture) {
+	t.Helper()
+
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to encode golden file: %v", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		t.Fatalf("failed to write golden file: %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"example/hello/internal/api"
+	"example/hello/internal/database"
+	"example/hello/internal/domain"
+)
+
+// playerShot builds a shot by name; measured shots get their geometry
+func playerShot(name string, minute int, shotType string, xg, x, y float64, measured bool) domain.DBXGStatShot {
+	shot := domain.DBXGStatShot{X: x, Y: y, XG: xg, ShotType: shotType, IsGoal: shotType == "goal", PlayerName: name, Minute: minute}
+	if measured {
+		shot.CoordinateSystem = "xgstat"
+		shot.DeriveGeometry()
+	}
+	return shot
+}
+
+// sakaFixtures hold four xgstat shots of Saka in two matches, saved out of
+// minute order in the second, and one in an Understat copy
+var sakaFixtures = []domain.DBXGStatFixture{
+	{Source: "xgstat", ID: 1, Gameweek: 1, Date: kickoff("2025-08-16 14:00"), HomeTeam: "Arsenal", AwayTeam: "Chelsea", HomeScore: 1, AwayScore: 0, HomeXG: 0.35, AwayXG: 0.2,
+		HomeShots: []domain.DBXGStatShot{
+			playerShot("Bukayo Saka", 10, "goal", 0.3, 90, 45, true),
+			playerShot("Bukayo Saka", 50, "off_target", 0.05, 75, 30, false),
+		},
+		AwayShots: []domain.DBXGStatShot{playerShot("Cole Palmer", 30, "on_target", 0.2, 85, 50, true)},
+	},
+	{Source: "xgstat", ID: 2, Gameweek: 2, Date: kickoff("2025-08-24 12:00"), HomeTeam: "Fulham", AwayTeam: "Arsenal", HomeScore: 0, AwayScore: 1, HomeXG: 0, AwayXG: 0.55,
+		HomeShots: []domain.DBXGStatShot{},
+		AwayShots: []domain.DBXGStatShot{
+			playerShot("Bukayo Saka", 70, "blocked", 0.1, 80, 60, true),
+			playerShot("Bukayo Saka", 5, "goal", 0.45, 94, 52, true),
+		},
+	},
+	{Source: "understat", ID: 2, Gameweek: 2, Date: kickoff("2025-08-24 12:00"), HomeTeam: "Fulham", AwayTeam: "Arsenal", HomeScore: 0, AwayScore: 1, HomeXG: 0, AwayXG: 0.5,
+		HomeShots: []domain.DBXGStatShot{},
+		AwayShots: []domain.DBXGStatShot{playerShot("Bukayo Saka", 5, "goal", 0.5, 94, 52, true)},
+	},
+}
+
+func TestPlayerShots(t *testing.T) {
+	for name, open := range repositories {
+		t.Run(name, func(t *testing.T) {
+			repo := open(t)
+			saveFixtures(t, repo, sakaFixtures)
+			ctx := context.Background()
+
+			shots, err := repo.PlayerShots(ctx, "Bukayo Saka", domain.PlayerFilter{Source: "xgstat"})
+			if err != nil {
+				t.Fatalf("PlayerShots returned error: %v", err)
+			}
+			var minutes []int
+			var opponents []string
+			for _, shot := range shots {
+				minutes = append(minutes, shot.Minute)
+				opponents = append(opponents, shot.Opponent)
+				if shot.Team != "Arsenal" || shot.Source != "xgstat" || shot.PlayerName != "Bukayo Saka" {
+					t.Errorf("shot %+v", shot)
+				}
+			}
+			if !reflect.DeepEqual(minutes, []int{10, 50, 5, 70}) || !reflect.DeepEqual(opponents, []string{"Chelsea", "Chelsea", "Fulham", "Fulham"}) {
+				t.Errorf("minutes %v against %v", minutes, opponents)
+			}
+			if shots[2].FixtureID != 2 || shots[2].Gameweek != 2 || !shots[2].Date.Equal(kickoff("2025-08-24 12:00")) || shots[2].DBXGStatShot != sakaFixtures[1].AwayShots[1] {
+				t.Errorf("third shot = %+v", shots[2])
+			}
+
+			for _, tt := range []struct {
+				filter domain.PlayerFilter
+				want   int
+			}{
+				{domain.PlayerFilter{}, 5},
+				{domain.PlayerFilter{Source: "xgstat", Team: "arsenal"}, 4},
+				{domain.PlayerFilter{Source: "xgstat", Team: "Fulham"}, 0},
+				{domain.PlayerFilter{Source: "xgstat", From: kickoff("2025-08-17 00:00")}, 2},
+				{domain.PlayerFilter{Source: "xgstat", Until: kickoff("2025-08-17 00:00")}, 2},
+			} {
+				shots, err := repo.PlayerShots(ctx, "Bukayo Saka", tt.filter)
+				if err != nil || len(shots) != tt.want {
+					t.Errorf("%+v: %d shots, %v; want %d", tt.filter, len(shots), err, tt.want)
+				}
+			}
+			if shots, err := repo.PlayerShots(ctx, "Nobody", domain.PlayerFilter{}); err != nil || shots == nil || len(shots) != 0 {
+				t.Errorf("unknown player: %v, %v; want no shots", shots, err)
+			}
+		})
+	}
+}
+
+func TestPlayerSummary(t *testing.T) {
+	var distance float64
+	for _, shot := range []domain.DBXGStatShot{sakaFixtures[0].HomeShots[0], sakaFixtures[1].AwayShots[0], sakaFixtures[1].AwayShots[1]} {
+		distance += shot.Distance
+	}
+	want := domain.PlayerSummary{
+		Player: "Bukayo Saka", Source: "xgstat",
+		Matches: 2, Shots: 4, Goals: 2, XG: 0.9, GoalsMinusXG: 1.1, ShotsPerMatch: 2,
+		AverageDistance: math.Round(distance/3*100) / 100,
+		ShotTypes: []domain.ShotTypeTotals{
+			{ShotType: "blocked", Shots: 1, XG: 0.1},
+			{ShotType: "goal", Shots: 2, Goals: 2, XG: 0.75},
+			{ShotType: "off_target", Shots: 1, XG: 0.05},
+		},
+	}
+
+	for name, open := range repositories {
+		t.Run(name, func(t *testing.T) {
+			repo := open(t)
+			saveFixtures(t, repo, sakaFixtures)
+			ctx := context.Background()
+
+			got, err := repo.PlayerSummary(ctx, "Bukayo Saka", domain.PlayerFilter{Source: "xgstat"})
+			if err != nil {
+				t.Fatalf("PlayerSummary returned error: %v", err)
+			}
+			if !reflect.DeepEqual(*got, want) {
+				t.Errorf("summary:\n got %+v\nwant %+v", *got, want)
+			}
+
+			got, err = repo.PlayerSummary(ctx, "Bukayo Saka", domain.PlayerFilter{Source: "xgstat", Until: kickoff("2025-08-17 00:00")})
+			if err != nil || got.Matches != 1 || got.Shots != 2 || got.GoalsMinusXG != 0.65 || got.AverageDistance != round2(sakaFixtures[0].HomeShots[0].Distance) {
+				t.Errorf("first match: %+v, %v", got, err)
+			}
+
+			for _, filter := range []domain.PlayerFilter{{Source: "statsbomb"}, {Team: "Chelsea"}} {
+				if _, err := repo.PlayerSummary(ctx, "Bukayo Saka", filter); !errors.Is(err, database.ErrPlayerNotFound) {
+					t.Errorf("%+v: %v, want ErrPlayerNotFound", filter, err)
+				}
+			}
+		})
+	}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func TestPlayerHandlers(t *testing.T) {
+	repo := database.NewMemory()
+	saveFixtures(t, repo, sakaFixtures)
+
+	handler := api.NewHandler(nil, nil, repo, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/players/{name}/shots", handler.GetPlayerShots)
+	mux.HandleFunc("/api/players/{name}/summary", handler.GetPlayerSummary)
+	get := func(target string, data any) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		json.NewDecoder(rec.Body).Decode(&struct {
+			Data any `json:"data"`
+		}{data})
+		return rec.Code
+	}
+
+	// Shots default to the understat source, as xgstat shots have no player
+	var shots []domain.PlayerShot
+	if code := get("/api/players/Bukayo%20Saka/shots?team=Arsenal&to=2025-08-24", &shots); code != http.StatusOK || len(shots) != 1 || shots[0].Source != domain.SourceUnderstat {
+		t.Errorf("shots: status %d, %+v", code, shots)
+	}
+	var summary domain.PlayerSummary
+	if code := get("/api/players/Bukayo%20Saka/summary", &summary); code != http.StatusOK || summary.Source != domain.SourceUnderstat || summary.Shots != 1 || summary.XG != 0.5 {
+		t.Errorf("summary without source: status %d, %+v", code, summary)
+	}
+	if code := get("/api/players/Bukayo%20Saka/summary?source=understat", &summary); code != http.StatusOK || summary.Shots != 1 {
+		t.Errorf("understat summary: status %d, %+v", code, summary)
+	}
+	for _, target := range []string{"/api/players/Bukayo%20Saka/shots?source=xgstat", "/api/players/Bukayo%20Saka/summary?source=xgstat"} {
+		if code := get(target, nil); code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", target, code)
+		}
+	}
+	if code := get("/api/players/Nobody/shots", &shots); code != http.StatusOK || len(shots) != 0 {
+		t.Errorf("unknown player shots: status %d, %d shots", code, len(shots))
+	}
+	if code := get("/api/players/Nobody/summary", nil); code != http.StatusNotFound {
+		t.Errorf("unknown player summary: status %d, want 404", code)
+	}
+	if code := get("/api/players/Bukayo%20Saka/summary?from=16/08/2025", nil); code != http.StatusBadRequest {
+		t.Errorf("invalid date: status %d, want 400", code)
+	}
+}
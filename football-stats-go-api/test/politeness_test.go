@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"example/hello/internal/scraper"
+)
+
+// robotsServer serves robots (404 when empty) and an empty page for every other path
+func robotsServer(t *testing.T, robots string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" && robots == "" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Path == "/robots.txt" {
+			w.Write([]byte(robots))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPolitenessRobots(t *testing.T) {
+	server := robotsServer(t, `# rules for everyone
+User-agent: *
+Disallow: /private
+Allow: /private/open$
+Crawl-delay: 0.1
+
+User-agent: football-stats
+User-agent: other-bot
+Disallow: /admin
+Crawl-delay: 0.1
+`)
+	politeness := scraper.NewPoliteness(scraper.PolitenessOptions{
+		MinInterval:   10 * time.Millisecond,
+		RespectRobots: true,
+		RobotsAgent:   "football-stats",
+	})
+	ctx := context.Background()
+
+	if err := politeness.Wait(ctx, server.URL+"/admin/users"); !errors.Is(err, scraper.ErrDisallowed) {
+		t.Errorf("/admin/users: got %v, want ErrDisallowed", err)
+	}
+	// Only the named group applies, so the * rules do not
+	if err := politeness.Wait(ctx, server.URL+"/private/page"); err != nil {
+		t.Errorf("/private/page: got %v, want it allowed", err)
+	}
+
+	start := time.Now()
+	for range 3 {
+		if err := politeness.Wait(ctx, server.URL+"/match"); err != nil {
+			t.Fatalf("Wait returned error: %v", err)
+		}
+	}
+	// The first /match request waits for the gap after /private/page
+	if elapsed := time.Since(start); elapsed < 250*time.Millisecond {
+		t.Errorf("3 requests took %s, want the 100ms crawl delay between each", elapsed)
+	}
+
+	states := politeness.State()
+	if len(states) != 1 {
+		t.Fatalf("got %d host states, want 1", len(states))
+	}
+	state := states[0]
+	if state.Requests != 4 || state.CrawlDelay != "100ms" || state.Interval != "100ms" || state.RobotsCheckedAt == nil {
+		t.Errorf("state = %+v", state)
+	}
+}
+
+func TestPolitenessWildcardRules(t *testing.T) {
+	server := robotsServer(t, "User-agent: *\nDisallow: /private\nAllow: /private/open$\nDisallow: /*.json$\n")
+	politeness := scraper.NewPoliteness(scraper.PolitenessOptions{RespectRobots: true, RobotsAgent: "football-stats"})
+
+	tests := []struct {
+		path    string
+		allowed bool
+	}{
+		{"/matches/arsenal", true},
+		{"/private", false},
+		{"/private/open", true},
+		{"/private/open/more", false},
+		{"/data/shots.json", false},
+		{"/data/shots.json?page=2", true},
+	}
+	for _, tt := range tests {
+		err := politeness.Wait(context.Background(), server.URL+tt.path)
+		if got := err == nil; got != tt.allowed {
+			t.Errorf("%s: got error %v, want allowed %v", tt.path, err, tt.allowed)
+		}
+	}
+}
+
+func TestPolitenessRateLimit(t *testing.T) {
+	server := robotsServer(t, "")
+	politeness := scraper.NewPoliteness(scraper.PolitenessOptions{
+		RequestsPerMinute: 600,
+		Burst:             2,
+		RespectRobots:     true,
+	})
+
+	start := time.Now()
+	for range 4 {
+		if err := politeness.Wait(context.Background(), server.URL+"/match"); err != nil {
+			t.Fatalf("Wait returned error: %v", err)
+		}
+	}
+	// Two requests go out as a burst, the next two wait 100ms each
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("4 requests took %s, want the rate limit to apply after the burst", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := politeness.Wait(ctx, server.URL+"/match"); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled Wait returned %v, want context.Canceled", err)
+	}
+}
+
+func TestPolitenessCancelledWaitKeepsSchedule(t *testing.T) {
+	politeness := scraper.NewPoliteness(scraper.PolitenessOptions{MinInterval: 200 * time.Millisecond})
+	url := "https://example.com/match"
+
+	start := time.Now()
+	if err := politeness.Wait(context.Background(), url); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+
+	// Each cancelled fetch gives up while waiting for the next slot
+	for range 3 {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		err := politeness.Wait(ctx, url)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("cancelled Wait returned %v, want context.DeadlineExceeded", err)
+		}
+	}
+
+	if err := politeness.Wait(context.Background(), url); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 350*time.Millisecond {
+		t.Errorf("next request waited until %s, want the cancelled waits not to push it back", elapsed)
+	}
+	if state := politeness.State(); len(state) != 1 || state[0].Requests != 2 || state[0].Waiting != 0 {
+		t.Errorf("state = %+v, want 2 requests and nobody waiting", state)
+	}
+}
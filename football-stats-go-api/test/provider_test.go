@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"example/hello/internal/api"
+	"example/hello/internal/domain"
+	"example/hello/internal/jobs"
+	"example/hello/internal/scraper"
+)
+
+func TestRegistryLookup(t *testing.T) {
+	registry := scraper.DefaultRegistry()
+
+	tests := map[string]string{
+		parserCases[0].url: "xgstat",
+		"https://xgstat.com/competitions/premier-league/2025-2026/matches/a-b-2026-01-24/advanced-analysis/shot-maps": "xgstat",
+		"https://understat.com/match/26631":                                                "understat",
+		"https://www.xgstat.com/competitions/premier-league/2025-2026/matches?gameweek=23": "",
+		"https://example.com/match/26631/advanced-analysis/shot-maps":                      "",
+		"not a url": "",
+	}
+	for url, want := range tests {
+		provider, err := registry.Lookup(url)
+		if want == "" {
+			if !errors.Is(err, scraper.ErrUnsupportedURL) {
+				t.Errorf("Lookup(%s) = %v, %v; want ErrUnsupportedURL", url, provider, err)
+			}
+			continue
+		}
+		if err != nil || provider.Name() != want {
+			t.Errorf("Lookup(%s) = %v, %v; want %s", url, provider, err, want)
+		}
+	}
+
+	registry.Register(localProvider{"https://example.com"})
+	if provider, err := registry.Lookup("https://example.com/match/1"); err != nil || provider.Name() != "local" {
+		t.Errorf("registered provider not found: %v, %v", provider, err)
+	}
+}
+
+func TestScrapeRejectsUnsupportedURL(t *testing.T) {
+	service := scraper.NewServiceWithFetcher(savedPageFetcher{}, time.Second, false)
+	queue := jobs.NewQueue(jobs.NewMemoryStore(), service, nil, jobs.Options{Workers: 1, QueueSize: 4})
+	if err := queue.Start(context.Background()); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	t.Cleanup(queue.Stop)
+	handler := api.NewHandler(service, nil, nil, queue)
+
+	if _, _, err := service.ScrapeXGStatFixture(context.Background(), "https://example.com/match/1"); !errors.Is(err, scraper.ErrUnsupportedURL) {
+		t.Errorf("ScrapeXGStatFixture error = %v, want ErrUnsupportedURL", err)
+	}
+
+	post := func(target, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		switch target {
+		case "/api/scrape/xgstats":
+			handler.ScrapeXGStats(rec, req)
+		default:
+			handler.BatchScrapeXGStats(rec, req)
+		}
+		return rec
+	}
+
+	if rec := post("/api/scrape/xgstats", `{"url": "https://example.com/match/1"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("unsupported URL: status %d, want 400", rec.Code)
+	}
+	if rec := post("/api/scrape/xgstats", `{"url": "https://understat.com/match/26631"}`); rec.Code != http.StatusAccepted {
+		t.Errorf("Understat URL: status %d, want 202", rec.Code)
+	}
+
+	rec := post("/api/scrape/batch", `{"urls": ["https://example.com/match/1", "`+parserCases[0].url+`"]}`)
+	var resp struct {
+		Data []api.BatchJob `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil || rec.Code != http.StatusAccepted || len(resp.Data) != 2 {
+		t.Fatalf("batch: status %d, %+v, %v", rec.Code, resp.Data, err)
+	}
+	if resp.Data[0].Error == "" || resp.Data[0].JobID != "" || resp.Data[1].JobID == "" {
+		t.Errorf("batch = %+v, want only the xgstat URL queued", resp.Data)
+	}
+}
+
+// slowListingFetcher holds back gameweek listings until release is closed and
+// serves the saved match pages otherwise
+type slowListingFetcher struct {
+	listings listingFetcher
+	release  chan struct{}
+}
+
+func (f slowListingFetcher) Fetch(ctx context.Context, url string) (string, error) {
+	if _, ok := f.listings[url]; !ok {
+		return savedPageFetcher{}.Fetch(ctx, url)
+	}
+	select {
+	case <-f.release:
+		return f.listings.Fetch(ctx, url)
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func TestBatchScrapeGameweekRunsInBackground(t *testing.T) {
+	listingURL := scraper.GameweekListingURL("premier-league", "2025-2026", 23)
+	fetcher := slowListingFetcher{
+		listings: listingFetcher{listingURL: listingPage("arsenal-manchester-united-2026-01-24", "arsenal-manchester-united-2026-01-24")},
+		release:  make(chan struct{}),
+	}
+	service := scraper.NewServiceWithFetcher(fetcher, time.Second, false)
+	queue := jobs.NewQueue(jobs.NewMemoryStore(), service, nil, jobs.Options{Workers: 1, QueueSize: 4})
+	if err := queue.Start(context.Background()); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	t.Cleanup(queue.Stop)
+	handler := api.NewHandler(service, nil, nil, queue)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.BatchScrapeXGStats(rec, httptest.NewRequest(http.MethodPost, "/api/scrape/batch", strings.NewReader(body)))
+		return rec
+	}
+
+	if rec := post(`{"season": "2025", "gameweek": 23}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid season: status %d, want 400", rec.Code)
+	}
+
+	// The listing is still held back, so the handler must not wait for it
+	rec := post(`{"season": "2025-2026", "gameweek": 23}`)
+	var resp struct {
+		Data []api.BatchJob `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil || rec.Code != http.StatusAccepted || len(resp.Data) != 1 {
+		t.Fatalf("gameweek: status %d, %+v, %v", rec.Code, resp.Data, err)
+	}
+	if resp.Data[0].URL != listingURL || resp.Data[0].JobID == "" {
+		t.Errorf("gameweek = %+v, want the batch job of %s", resp.Data, listingURL)
+	}
+
+	close(fetcher.release)
+	batch := waitForJob(t, queue, resp.Data[0].JobID)
+	if batch.Status != jobs.StatusSucceeded || !batch.Batch || len(batch.Jobs) != 1 {
+		t.Fatalf("batch job = %+v, want one queued match", batch)
+	}
+	if done := waitForJob(t, queue, batch.Jobs[0]); done.Status != jobs.StatusSucceeded {
+		t.Errorf("match job status = %q (error %q), want %q", done.Status, done.Error, jobs.StatusSucceeded)
+	}
+}
+
+// reportlessProvider parses like localProvider but leaves out the report
+type reportlessProvider struct{ localProvider }
+
+func (p reportlessProvider) Parse(pageData, url string) (*domain.DBXGStatFixture, *scraper.ParseReport, error) {
+	fixture, _, err := p.localProvider.Parse(pageData, url)
+	return fixture, nil, err
+}
+
+func TestScrapeWithoutParseReport(t *testing.T) {
+	service := scraper.NewServiceWithFetcher(savedPageFetcher{}, time.Second, false)
+	service.SetProviders(scraper.NewRegistry(reportlessProvider{localProvider{"https://www.xgstat.com"}}))
+
+	fixture, report, err := service.ScrapeXGStatFixture(context.Background(), parserCases[0].url)
+	if err != nil {
+		t.Fatalf("ScrapeXGStatFixture returned error: %v", err)
+	}
+	if fixture == nil || report == nil {
+		t.Fatalf("got fixture %v and report %v, want both", fixture, report)
+	}
+	if report.Complete || len(report.Issues) != 1 {
+		t.Errorf("report = %+v, want an incomplete report with one issue", report)
+	}
+}
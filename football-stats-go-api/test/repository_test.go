@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"example/hello/internal/api"
+	"example/hello/internal/database"
+	"example/hello/internal/domain"
+	"example/hello/internal/scraper"
+	"example/hello/internal/statsbomb"
+)
+
+// repositoryFixture is the StatsBomb test match, which sets every shot field
+func repositoryFixture(t *testing.T) *domain.DBXGStatFixture {
+	t.Helper()
+	matches, err := statsbomb.Matches(statsbombDir, 2, 27)
+	if err != nil {
+		t.Fatalf("Matches returned error: %v", err)
+	}
+	fixture, err := statsbomb.Fixture(statsbombDir, matches[0])
+	if err != nil {
+		t.Fatalf("Fixture returned error: %v", err)
+	}
+	return fixture
+}
+
+// repositories opens an empty repository of each backend that runs without a
+// server
+var repositories = map[string]func(t *testing.T) database.FixtureRepository{
+	"memory": func(t *testing.T) database.FixtureRepository {
+		return database.NewMemory()
+	},
+	"sqlite": func(t *testing.T) database.FixtureRepository {
+		store, err := database.NewSQLite(filepath.Join(t.TempDir(), "football.db"), 5*time.Second)
+		if err != nil {
+			t.Fatalf("NewSQLite returned error: %v", err)
+		}
+		t.Cleanup(func() { store.Close() })
+		return store
+	},
+}
+
+func TestFixtureRepositories(t *testing.T) {
+	for name, open := range repositories {
+		t.Run(name, func(t *testing.T) {
+			repo := open(t)
+			ctx := context.Background()
+			fixture := repositoryFixture(t)
+
+			if _, err := repo.GetFixtureByID(ctx, fixture.Source, fixture.ID); !errors.Is(err, database.ErrFixtureNotFound) {
+				t.Fatalf("GetFixtureByID before save: %v, want ErrFixtureNotFound", err)
+			}
+			if err := repo.SaveXGStatFixture(ctx, fixture); err != nil {
+				t.Fatalf("SaveXGStatFixture returned error: %v", err)
+			}
+
+			got, err := repo.GetFixtureByID(ctx, fixture.Source, fixture.ID)
+			if err != nil {
+				t.Fatalf("GetFixtureByID returned error: %v", err)
+			}
+			if !got.Date.Equal(fixture.Date) {
+				t.Errorf("Date = %v, want %v", got.Date, fixture.Date)
+			}
+			got.Date = fixture.Date
+			if !reflect.DeepEqual(got, fixture) {
+				t.Errorf("round trip changed the fixture:\n got %+v\nwant %+v", got, fixture)
+			}
+
+			// The same ID from another source is another fixture
+			if _, err := repo.GetFixtureByID(ctx, domain.SourceXGStat, fixture.ID); !errors.Is(err, database.ErrFixtureNotFound) {
+				t.Errorf("GetFixtureByID(xgstat): %v, want ErrFixtureNotFound", err)
+			}
+
+			// Saving again replaces the fixture and its shots
+			update := repositoryFixture(t)
+			update.HomeScore = 9
+			update.HomeShots = update.HomeShots[:1]
+			if err := repo.SaveXGStatFixture(ctx, update); err != nil {
+				t.Fatalf("second SaveXGStatFixture returned error: %v", err)
+			}
+			got, err = repo.GetFixtureByID(ctx, fixture.Source, fixture.ID)
+			if err != nil {
+				t.Fatalf("GetFixtureByID after update returned error: %v", err)
+			}
+			if got.HomeScore != 9 || len(got.HomeShots) != 1 || len(got.AwayShots) != len(fixture.AwayShots) {
+				t.Errorf("after update: score %d, %d home and %d away shots", got.HomeScore, len(got.HomeShots), len(got.AwayShots))
+			}
+
+			// Shots without a minute come last; a source defaults to xgstat
+			unsourced := &domain.DBXGStatFixture{
+				ID:        7,
+				Gameweek:  1,
+				Date:      time.Date(2026, 1, 24, 15, 0, 0, 0, time.UTC),
+				HomeTeam:  "Home",
+				AwayTeam:  "Away",
+				HomeShots: []domain.DBXGStatShot{{X: 90, Y: 50, XG: 0.1, ShotType: "off_target"}, {X: 80, Y: 40, XG: 0.2, ShotType: "blocked", Minute: 10}},
+				AwayShots: []domain.DBXGStatShot{},
+			}
+			if err := repo.SaveXGStatFixture(ctx, unsourced); err != nil {
+				t.Fatalf("SaveXGStatFixture without source returned error: %v", err)
+			}
+			got, err = repo.GetFixtureByID(ctx, domain.SourceXGStat, 7)
+			if err != nil {
+				t.Fatalf("GetFixtureByID(xgstat, 7) returned error: %v", err)
+			}
+			if got.Source != domain.SourceXGStat || len(got.HomeShots) != 2 || got.HomeShots[0].Minute != 10 || got.HomeShots[1].Minute != 0 {
+				t.Errorf("unsourced fixture = %+v", got)
+			}
+		})
+	}
+}
+
+// TestFixtureRepositoriesKeepSameDayMatches saves two xgstat matches whose
+// URLs end in the same day of the month, which must not replace each other
+func TestFixtureRepositoriesKeepSameDayMatches(t *testing.T) {
+	for name, open := range repositories {
+		t.Run(name, func(t *testing.T) {
+			repo := open(t)
+			ctx := context.Background()
+			fixtures := sameDayFixtures(t)
+
+			for _, fixture := range fixtures {
+				if err := repo.SaveXGStatFixture(ctx, fixture); err != nil {
+					t.Fatalf("SaveXGStatFixture(%s) returned error: %v", fixture.MatchKey, err)
+				}
+			}
+			for _, fixture := range fixtures {
+				got, err := repo.GetFixtureByID(ctx, domain.SourceXGStat, fixture.ID)
+				if err != nil {
+					t.Fatalf("GetFixtureByID(%d) returned error: %v", fixture.ID, err)
+				}
+				if got.MatchKey != fixture.MatchKey || len(got.HomeShots) != len(fixture.HomeShots) {
+					t.Errorf("GetFixtureByID(%d) = match %q with %d home shots, want %q with %d",
+						fixture.ID, got.MatchKey, len(got.HomeShots), fixture.MatchKey, len(fixture.HomeShots))
+				}
+			}
+
+			page, err := repo.ListFixtures(ctx, domain.FixtureFilter{Source: domain.SourceXGStat})
+			if err != nil {
+				t.Fatalf("ListFixtures returned error: %v", err)
+			}
+			if len(page.Fixtures) != len(fixtures) {
+				t.Errorf("listed %d fixtures, want %d", len(page.Fixtures), len(fixtures))
+			}
+		})
+	}
+}
+
+// TestFixtureRepositoriesSharedFixtureID saves two same-day matches under one
+// fixture ID, as rows saved before match keys can have, and expects the ID
+// lookup to report the conflict and the key lookup to find each match
+func TestFixtureRepositoriesSharedFixtureID(t *testing.T) {
+	for name, open := range repositories {
+		t.Run(name, func(t *testing.T) {
+			repo := open(t)
+			ctx := context.Background()
+			fixtures := sameDayFixtures(t)
+			fixtures[1].ID = fixtures[0].ID
+
+			for _, fixture := range fixtures {
+				if err := repo.SaveXGStatFixture(ctx, fixture); err != nil {
+					t.Fatalf("SaveXGStatFixture(%s) returned error: %v", fixture.MatchKey, err)
+				}
+			}
+
+			_, err := repo.GetFixtureByID(ctx, domain.SourceXGStat, fixtures[0].ID)
+			if !errors.Is(err, database.ErrFixtureAmbiguous) {
+				t.Fatalf("GetFixtureByID error = %v, want ErrFixtureAmbiguous", err)
+			}
+			for _, fixture := range fixtures {
+				if !strings.Contains(err.Error(), fixture.MatchKey) {
+					t.Errorf("error %q does not list match %q", err, fixture.MatchKey)
+				}
+				got, err := repo.GetFixtureByKey(ctx, domain.SourceXGStat, fixture.MatchKey)
+				if err != nil {
+					t.Fatalf("GetFixtureByKey(%s) returned error: %v", fixture.MatchKey, err)
+				}
+				if got.MatchKey != fixture.MatchKey {
+					t.Errorf("GetFixtureByKey(%s) = match %q", fixture.MatchKey, got.MatchKey)
+				}
+			}
+
+			handler := api.NewHandler(nil, nil, repo, nil)
+			for target, want := range map[string]int{
+				fmt.Sprintf("/api/xgstats?id=%d", fixtures[0].ID):     http.StatusConflict,
+				"/api/xgstats?match_key=" + fixtures[1].MatchKey:      http.StatusOK,
+				"/api/xgstats?match_key=liverpool-chelsea-2099-01-01": http.StatusNotFound,
+			} {
+				rec := httptest.NewRecorder()
+				handler.GetXGStatFixture(rec, httptest.NewRequest(http.MethodGet, target, nil))
+				if rec.Code != want {
+					t.Errorf("%s: status %d, want %d", target, rec.Code, want)
+				}
+			}
+		})
+	}
+}
+
+// TestSQLiteRejectsFixtureIDKey refuses a database file whose fixtures are
+// still keyed on (source, fixture_id)
+func TestSQLiteRejectsFixtureIDKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "football.db")
+	db, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	_, err = db.Exec(`CREATE TABLE xgstat_fixtures (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		source VARCHAR(20) NOT NULL DEFAULT 'xgstat',
+		fixture_id INT NOT NULL,
+		UNIQUE (source, fixture_id)
+	)`)
+	db.Close()
+	if err != nil {
+		t.Fatalf("failed to create old table: %v", err)
+	}
+
+	if store, err := database.NewSQLite(path, 5*time.Second); err == nil {
+		store.Close()
+		t.Error("NewSQLite opened a database keyed on fixture_id")
+	}
+}
+
+func TestGetFixtureWithoutDatabase(t *testing.T) {
+	service := scraper.NewServiceWithFetcher(savedPageFetcher{}, time.Second, false)
+	get := func(handler *api.Handler, target string) int {
+		rec := httptest.NewRecorder()
+		handler.GetXGStatFixture(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec.Code
+	}
+
+	if code := get(api.NewHandler(service, nil, nil, nil), "/api/xgstats?id=1"); code != http.StatusServiceUnavailable {
+		t.Errorf("no database: status %d, want 503", code)
+	}
+
+	repo := database.NewMemory()
+	fixture := repositoryFixture(t)
+	if err := repo.SaveXGStatFixture(context.Background(), fixture); err != nil {
+		t.Fatalf("SaveXGStatFixture returned error: %v", err)
+	}
+	handler := api.NewHandler(service, nil, repo, nil)
+	if code := get(handler, "/api/xgstats?id=3754058&source=statsbomb"); code != http.StatusOK {
+		t.Errorf("saved fixture: status %d, want 200", code)
+	}
+	if code := get(handler, "/api/xgstats?id=3754058"); code != http.StatusNotFound {
+		t.Errorf("fixture of another source: status %d, want 404", code)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"example/hello/internal/config"
+	"example/hello/internal/domain"
+	"example/hello/internal/jobs"
+	"example/hello/internal/scheduler"
+	"example/hello/internal/scraper"
+)
+
+func TestCronNext(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"*/30 * * * *", time.Date(2025, 8, 16, 12, 0, 0, 0, time.UTC), time.Date(2025, 8, 16, 12, 30, 0, 0, time.UTC)},
+		{"*/30 * * * *", time.Date(2025, 8, 16, 12, 45, 10, 0, time.UTC), time.Date(2025, 8, 16, 13, 0, 0, 0, time.UTC)},
+		{"0 6 * * *", time.Date(2025, 8, 16, 6, 0, 0, 0, time.UTC), time.Date(2025, 8, 17, 6, 0, 0, 0, time.UTC)},
+		{"0 6 * * 1-5", time.Date(2025, 8, 16, 7, 0, 0, 0, time.UTC), time.Date(2025, 8, 18, 6, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 6 * * *", time.Date(2025, 10, 25, 12, 0, 0, 0, london), time.Date(2025, 10, 26, 6, 0, 0, 0, london)},
+	}
+
+	for _, tt := range tests {
+		schedule, err := scheduler.ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+		}
+		if got := schedule.Next(tt.from); !got.Equal(tt.want) {
+			t.Errorf("%q.Next(%s) = %s, want %s", tt.expr, tt.from, got, tt.want)
+		}
+	}
+
+	never, err := scheduler.ParseCron("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := never.Next(time.Now()); !got.IsZero() {
+		t.Errorf("30 February fired at %s", got)
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := scheduler.ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) returned no error", expr)
+		}
+	}
+}
+
+func TestDiscoverGameweekKickoff(t *testing.T) {
+	listing := `<html><body>
+		<div class="match"><time datetime="2025-08-15T20:00:00+01:00">Fri 20:00</time>
+			<a href="/competitions/premier-league/2025-2026/matches/liverpool-bournemouth-2025-08-15">LIV v BOU</a></div>
+		<div class="match"><a href="/competitions/premier-league/2025-2026/matches/aston-villa-newcastle-2025-08-16">AVL v NEW</a></div>
+	</body></html>`
+	fetcher := listingFetcher{scraper.GameweekListingURL("premier-league", "2025-2026", 1): listing}
+	service := scraper.NewServiceWithFetcher(fetcher, time.Second, false)
+
+	fixtures, err := service.DiscoverGameweek(context.Background(), "premier-league", "2025-2026", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) != 2 {
+		t.Fatalf("got %d fixtures, want 2", len(fixtures))
+	}
+
+	want := map[string]time.Time{
+		// Listed kick-off time
+		"liverpool-bournemouth-2025-08-15": time.Date(2025, 8, 15, 19, 0, 0, 0, time.UTC),
+		// No time listed: end of the match day in London
+		"aston-villa-newcastle-2025-08-16": time.Date(2025, 8, 16, 23, 0, 0, 0, time.UTC),
+	}
+	for _, fixture := range fixtures {
+		for slug, kickoff := range want {
+			if fixture.URL != scraper.ShotMapURL("premier-league", "2025-2026", slug) {
+				continue
+			}
+			if fixture.Kickoff == nil || !fixture.Kickoff.Equal(kickoff) {
+				t.Errorf("%s kickoff = %v, want %s", slug, fixture.Kickoff, kickoff)
+			}
+		}
+	}
+}
+
+func TestScrapeDueSkipsQueuedFixtures(t *testing.T) {
+	store := &memoryDiscoveryStore{fixtures: make(map[string]domain.DBDiscoveredFixture)}
+	store.SaveDiscoveredFixtures(context.Background(), []domain.DBDiscoveredFixture{
+		{URL: parserCases[0].url, Status: domain.DiscoveryPending},
+		{URL: parserCases[1].url, Status: domain.DiscoveryPending},
+	})
+
+	// The queue is not started, so jobs stay queued as behind a busy worker pool
+	jobStore := jobs.NewMemoryStore()
+	queue := jobs.NewQueue(jobStore, nil, nil, jobs.Options{QueueSize: 8})
+	tasks, err := scheduler.Tasks(config.SchedulerConfig{ScrapeDueCron: "*/30 * * * *", ScrapeDelay: time.Hour}, scheduler.Deps{
+		Fixtures: store,
+		Queue:    queue,
+	})
+	if err != nil || len(tasks) != 1 || tasks[0].Name != scheduler.TaskScrapeDue {
+		t.Fatalf("Tasks = %+v, %v; want the scrape_due task", tasks, err)
+	}
+
+	for run := 0; run < 2; run++ {
+		if err := tasks[0].Run(context.Background()); err != nil {
+			t.Fatalf("run %d returned error: %v", run, err)
+		}
+	}
+
+	unfinished, err := jobStore.ListUnfinishedJobs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unfinished) != 2 {
+		t.Errorf("got %d queued jobs after two runs, want 2", len(unfinished))
+	}
+}
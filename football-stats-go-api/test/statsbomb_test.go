@@ -0,0 +1,119 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"example/hello/internal/domain"
+	"example/hello/internal/statsbomb"
+)
+
+var statsbombDir = filepath.Join("testdata", "statsbomb")
+
+func TestStatsBombMatches(t *testing.T) {
+	matches, err := statsbomb.Matches(statsbombDir, 2, 27)
+	if err != nil {
+		t.Fatalf("Matches returned error: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2", len(matches))
+	}
+
+	want := statsbomb.Match{
+		ID:        3754058,
+		Date:      time.Date(2016, 1, 2, 16, 0, 0, 0, time.UTC),
+		Week:      20,
+		HomeTeam:  "Liverpool",
+		AwayTeam:  "West Ham United",
+		HomeScore: 0,
+		AwayScore: 2,
+	}
+	if matches[0] != want {
+		t.Errorf("match = %+v, want %+v", matches[0], want)
+	}
+
+	if _, err := statsbomb.Fixture(statsbombDir, matches[1]); err == nil {
+		t.Errorf("Fixture without an events file returned no error")
+	}
+	if _, err := statsbomb.Matches(statsbombDir, 2, 1); err == nil {
+		t.Errorf("Matches of a missing season returned no error")
+	}
+}
+
+func TestStatsBombFixture(t *testing.T) {
+	matches, err := statsbomb.Matches(statsbombDir, 2, 27)
+	if err != nil {
+		t.Fatalf("Matches returned error: %v", err)
+	}
+
+	f, err := statsbomb.Fixture(statsbombDir, matches[0])
+	if err != nil {
+		t.Fatalf("Fixture returned error: %v", err)
+	}
+
+	if f.Source != domain.SourceStatsBomb || f.ID != 3754058 || f.Gameweek != 20 {
+		t.Errorf("source, id, gameweek = %s, %d, %d", f.Source, f.ID, f.Gameweek)
+	}
+	if len(f.HomeShots) != 3 || len(f.AwayShots) != 2 {
+		t.Fatalf("got %d/%d shots, want 3/2", len(f.HomeShots), len(f.AwayShots))
+	}
+	if f.HomeXG != 0.178 || f.AwayXG != 0.39 {
+		t.Errorf("xG = %.3f-%.3f, want the summed shot xG 0.178-0.390", f.HomeXG, f.AwayXG)
+	}
+
+	header := f.AwayShots[0]
+	want := domain.DBXGStatShot{
+		X:                93.333,
+		Y:                47.5,
+		XG:               0.266,
+		IsGoal:           true,
+		ShotType:         "goal",
+		PlayerName:       "Michail Antonio",
+		Minute:           10,
+		Situation:        domain.SituationCorner,
+		BodyPart:         domain.BodyPartHead,
+		PlayPattern:      "from_corner",
+		CoordinateSystem: "statsbomb",
+	}
+	header.Distance, header.Angle, header.Zone = 0, 0, ""
+	if header != want {
+		t.Errorf("header = %+v\nwant %+v", header, want)
+	}
+
+	wantSituations := []string{domain.SituationSetPiece, domain.SituationDirectFreeKick, domain.SituationOpenPlay}
+	wantTypes := []string{"on_target", "off_target", "blocked"}
+	for i, shot := range f.HomeShots {
+		if shot.Situation != wantSituations[i] || shot.ShotType != wantTypes[i] {
+			t.Errorf("home shot %d: situation %s, type %s; want %s, %s", i, shot.Situation, shot.ShotType, wantSituations[i], wantTypes[i])
+		}
+		if shot.Zone == "" {
+			t.Errorf("home shot %d has no geometry", i)
+		}
+	}
+}
+
+func TestStatsBombSkipsShootout(t *testing.T) {
+	match := statsbomb.Match{ID: 1, HomeTeam: "Home", AwayTeam: "Away"}
+	events := `[
+		{"period": 4, "minute": 118, "type": {"name": "Shot"}, "team": {"name": "Home"}, "player": {"name": "A"},
+		 "location": [100, 40], "shot": {"statsbomb_xg": 0.1, "outcome": {"name": "Off T"}, "type": {"name": "Open Play"}}},
+		{"period": 5, "minute": 120, "type": {"name": "Shot"}, "team": {"name": "Away"}, "player": {"name": "B"},
+		 "location": [108, 40], "shot": {"statsbomb_xg": 0.78, "outcome": {"name": "Goal"}, "type": {"name": "Penalty"}}}
+	]`
+
+	f, err := statsbomb.ParseEvents(match, []byte(events))
+	if err != nil {
+		t.Fatalf("ParseEvents returned error: %v", err)
+	}
+	if len(f.HomeShots) != 1 || len(f.AwayShots) != 0 {
+		t.Fatalf("got %d/%d shots, want the shoot-out kick left out", len(f.HomeShots), len(f.AwayShots))
+	}
+	if f.HomeShots[0].Minute != 119 {
+		t.Errorf("minute = %d, want 119", f.HomeShots[0].Minute)
+	}
+
+	if _, err := statsbomb.ParseEvents(match, []byte(`[{"period": 1, "type": {"name": "Shot"}, "team": {"name": "Other"}, "location": [100, 40], "shot": {}}]`)); err == nil {
+		t.Errorf("shot by an unknown team returned no error")
+	}
+}
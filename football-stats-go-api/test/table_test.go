@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"example/hello/internal/api"
+	"example/hello/internal/database"
+	"example/hello/internal/domain"
+	"example/hello/internal/table"
+)
+
+// shotsXG builds one shot per xG value
+func shotsXG(xgs ...float64) []domain.DBXGStatShot {
+	shots := []domain.DBXGStatShot{}
+	for _, xg := range xgs {
+		shots = append(shots, domain.DBXGStatShot{X: 88, Y: 50, XG: xg, ShotType: "off_target"})
+	}
+	return shots
+}
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestGoalDistribution(t *testing.T) {
+	tests := []struct {
+		xgs  []float64
+		want []float64
+	}{
+		{nil, []float64{1}},
+		{[]float64{0.3}, []float64{0.7, 0.3}},
+		{[]float64{0.5, 0.5}, []float64{0.25, 0.5, 0.25}},
+		{[]float64{0.2, 0.5, 1}, []float64{0, 0.4, 0.5, 0.1}},
+	}
+	for _, tt := range tests {
+		got := table.GoalDistribution(shotsXG(tt.xgs...))
+		if len(got) != len(tt.want) {
+			t.Errorf("%v: got %v, want %v", tt.xgs, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if !closeTo(got[i], tt.want[i]) {
+				t.Errorf("%v: got %v, want %v", tt.xgs, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestMatchProbabilities(t *testing.T) {
+	tests := []struct {
+		home, away []float64
+		want       table.Probabilities
+	}{
+		{nil, nil, table.Probabilities{Draw: 1}},
+		{[]float64{0.5}, nil, table.Probabilities{HomeWin: 0.5, Draw: 0.5}},
+		{[]float64{0.5, 0.5}, []float64{0.5}, table.Probabilities{HomeWin: 0.5, Draw: 0.375, AwayWin: 0.125}},
+	}
+	for _, tt := range tests {
+		got := table.MatchProbabilities(domain.DBXGStatFixture{HomeShots: shotsXG(tt.home...), AwayShots: shotsXG(tt.away...)})
+		if !closeTo(got.HomeWin, tt.want.HomeWin) || !closeTo(got.Draw, tt.want.Draw) || !closeTo(got.AwayWin, tt.want.AwayWin) {
+			t.Errorf("%v v %v: got %+v, want %+v", tt.home, tt.away, got, tt.want)
+		}
+	}
+}
+
+// xgstat.com's shot markers carry no xG, so its matches fall back to the
+// teams' xG
+func TestMatchProbabilitiesWithoutShotXG(t *testing.T) {
+	fixture := domain.DBXGStatFixture{HomeTeam: "Arsenal", AwayTeam: "Brentford", HomeScore: 1, HomeXG: 1, AwayXG: 0,
+		HomeShots: shotsXG(0, 0, 0), AwayShots: shotsXG(0)}
+
+	got := table.MatchProbabilities(fixture)
+	want := table.Probabilities{HomeWin: 1 - math.Exp(-1), Draw: math.Exp(-1), Estimated: true}
+	if !closeTo(got.HomeWin, want.HomeWin) || !closeTo(got.Draw, want.Draw) || !closeTo(got.AwayWin, want.AwayWin) || !got.Estimated {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	rows := table.Build([]domain.DBXGStatFixture{fixture}, table.VenueAll)
+	if len(rows) != 2 || rows[0].XPts != 2.26 || rows[1].XPts != 0.37 || rows[0].XPtsEstimated != 1 || rows[1].XPtsEstimated != 1 {
+		t.Errorf("table: %+v", rows)
+	}
+
+	// Shots with xG are used as they are, even when others have none
+	fixture.HomeShots = shotsXG(0.5, 0, 0)
+	if got := table.MatchProbabilities(fixture); !closeTo(got.HomeWin, 0.5) || got.Estimated {
+		t.Errorf("shots with xG: got %+v", got)
+	}
+
+	for _, xg := range []float64{0.3, 1.6, 4} {
+		dist := table.PoissonDistribution(xg)
+		total, mean := 0.0, 0.0
+		for n, p := range dist {
+			total += p
+			mean += float64(n) * p
+		}
+		if math.Abs(total-1) > 1e-8 || math.Abs(mean-xg) > 1e-6 {
+			t.Errorf("Poisson(%v): total %v, mean %v", xg, total, mean)
+		}
+	}
+}
+
+// tableFixtures are three matches of three teams
+var tableFixtures = []domain.DBXGStatFixture{
+	{Source: "xgstat", ID: 1, Gameweek: 1, Date: kickoff("2025-08-16 14:00"), HomeTeam: "Arsenal", AwayTeam: "Brentford", HomeScore: 1, AwayScore: 0, HomeXG: 0.5, AwayXG: 0,
+		HomeShots: shotsXG(0.5), AwayShots: shotsXG()},
+	{Source: "xgstat", ID: 2, Gameweek: 2, Date: kickoff("2025-08-23 14:00"), HomeTeam: "Brentford", AwayTeam: "Chelsea", HomeScore: 2, AwayScore: 2, HomeXG: 1, AwayXG: 0.5,
+		HomeShots: shotsXG(0.5, 0.5), AwayShots: shotsXG(0.5)},
+	{Source: "xgstat", ID: 3, Gameweek: 3, Date: kickoff("2025-08-30 14:00"), HomeTeam: "Chelsea", AwayTeam: "Arsenal", HomeScore: 0, AwayScore: 1, HomeXG: 0, AwayXG: 0,
+		HomeShots: shotsXG(), AwayShots: shotsXG()},
+}
+
+func TestBuildTable(t *testing.T) {
+	all := []table.Row{
+		{Position: 1, Team: "Arsenal", Played: 2, Won: 2, GoalsFor: 2, GoalDifference: 2, Points: 6, XGFor: 0.5, XPts: 3, PointsMinusXPts: 3},
+		{Position: 2, Team: "Brentford", Played: 2, Drawn: 1, Lost: 1, GoalsFor: 2, GoalsAgainst: 3, GoalDifference: -1, Points: 1, XGFor: 1, XGAgainst: 1, XPts: 2.38, PointsMinusXPts: -1.38},
+		{Position: 3, Team: "Chelsea", Played: 2, Drawn: 1, Lost: 1, GoalsFor: 2, GoalsAgainst: 3, GoalDifference: -1, Points: 1, XGFor: 0.5, XGAgainst: 1, XPts: 1.75, PointsMinusXPts: -0.75},
+	}
+	if got := table.Build(tableFixtures, table.VenueAll); !reflect.DeepEqual(got, all) {
+		t.Errorf("all matches:\n got %+v\nwant %+v", got, all)
+	}
+
+	standings := func(rows []table.Row) [][3]any {
+		var got [][3]any
+		for _, row := range rows {
+			got = append(got, [3]any{row.Team, row.Points, row.XPts})
+		}
+		return got
+	}
+	home := [][3]any{{"Arsenal", 3, 2.0}, {"Brentford", 1, 1.88}, {"Chelsea", 0, 1.0}}
+	if got := standings(table.Build(tableFixtures, table.VenueHome)); !reflect.DeepEqual(got, home) {
+		t.Errorf("home matches: got %v, want %v", got, home)
+	}
+	away := [][3]any{{"Arsenal", 3, 1.0}, {"Chelsea", 1, 0.75}, {"Brentford", 0, 0.5}}
+	if got := standings(table.Build(tableFixtures, table.VenueAway)); !reflect.DeepEqual(got, away) {
+		t.Errorf("away matches: got %v, want %v", got, away)
+	}
+
+	if got := table.Build(nil, table.VenueAll); got == nil || len(got) != 0 {
+		t.Errorf("no fixtures: got %v, want an empty table", got)
+	}
+}
+
+func TestSeasonFixtures(t *testing.T) {
+	for name, open := range repositories {
+		t.Run(name, func(t *testing.T) {
+			repo := open(t)
+			saveFixtures(t, repo, arsenalFixtures)
+
+			fixtures, err := repo.SeasonFixtures(context.Background(), domain.SeasonFilter{Source: "xgstat", FromGameweek: 2})
+			if err != nil {
+				t.Fatalf("SeasonFixtures returned error: %v", err)
+			}
+			if len(fixtures) != 2 || fixtures[0].ID != 2 || fixtures[1].ID != 3 {
+				t.Fatalf("got %d fixtures: %+v", len(fixtures), fixtures)
+			}
+			if len(fixtures[0].HomeShots) != 1 || len(fixtures[0].AwayShots) != 3 || len(fixtures[1].HomeShots) != 2 || len(fixtures[1].AwayShots) != 2 {
+				t.Errorf("shots not attached to their fixtures: %+v", fixtures)
+			}
+
+			for _, tt := range []struct {
+				filter domain.SeasonFilter
+				want   int
+			}{
+				{domain.SeasonFilter{}, 4},
+				{domain.SeasonFilter{Source: "xgstat", ToGameweek: 1}, 1},
+				{domain.SeasonFilter{Source: "xgstat", FromGameweek: 4}, 0},
+			} {
+				fixtures, err := repo.SeasonFixtures(context.Background(), tt.filter)
+				if err != nil || len(fixtures) != tt.want {
+					t.Errorf("%+v: %d fixtures, %v; want %d", tt.filter, len(fixtures), err, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLeagueTableHandler(t *testing.T) {
+	repo := database.NewMemory()
+	saveFixtures(t, repo, tableFixtures)
+	handler := api.NewHandler(nil, nil, repo, nil)
+
+	get := func(target string) (int, []table.Row) {
+		rec := httptest.NewRecorder()
+		handler.GetLeagueTable(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		var resp struct {
+			Data []table.Row `json:"data"`
+		}
+		json.NewDecoder(rec.Body).Decode(&resp)
+		return rec.Code, resp.Data
+	}
+
+	code, rows := get("/api/table?from_gameweek=2&venue=away")
+	if code != http.StatusOK || len(rows) != 2 || rows[0].Team != "Arsenal" || rows[1].Team != "Chelsea" || rows[1].XPts != 0.75 {
+		t.Errorf("away table of gameweeks 2-3: status %d, %+v", code, rows)
+	}
+	if code, rows := get("/api/table?source=understat"); code != http.StatusOK || rows == nil || len(rows) != 0 {
+		t.Errorf("source without fixtures: status %d, %+v", code, rows)
+	}
+	for _, target := range []string{
+		"/api/table?venue=neutral",
+		"/api/table?to_gameweek=last",
+		"/api/table?from_gameweek=3&to_gameweek=2",
+	} {
+		if code, _ := get(target); code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", target, code)
+		}
+	}
+}
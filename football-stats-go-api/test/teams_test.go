@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"example/hello/internal/api"
+	"example/hello/internal/database"
+	"example/hello/internal/domain"
+)
+
+// shotsOf builds one shot per shot type
+func shotsOf(types ...string) []domain.DBXGStatShot {
+	shots := []domain.DBXGStatShot{}
+	for _, shotType := range types {
+		shots = append(shots, domain.DBXGStatShot{X: 88, Y: 50, XG: 0.1, ShotType: shotType, IsGoal: shotType == "goal"})
+	}
+	return shots
+}
+
+// arsenalFixtures are three xgstat matches of Arsenal, and an Understat copy
+// of the second that summaries of xgstat fixtures leave out
+var arsenalFixtures = []domain.DBXGStatFixture{
+	{Source: "xgstat", ID: 1, Gameweek: 1, Date: kickoff("2025-08-16 14:00"), HomeTeam: "Arsenal", AwayTeam: "Chelsea", HomeScore: 2, AwayScore: 1, HomeXG: 1.1, AwayXG: 2.2,
+		HomeShots: shotsOf("goal", "goal", "off_target"), AwayShots: shotsOf("goal", "on_target")},
+	{Source: "xgstat", ID: 2, Gameweek: 2, Date: kickoff("2025-08-24 12:00"), HomeTeam: "Fulham", AwayTeam: "Arsenal", HomeScore: 1, AwayScore: 1, HomeXG: 0.9, AwayXG: 1.3,
+		HomeShots: shotsOf("goal"), AwayShots: shotsOf("goal", "on_target", "blocked")},
+	{Source: "xgstat", ID: 3, Gameweek: 3, Date: kickoff("2025-08-30 19:45"), HomeTeam: "Arsenal", AwayTeam: "Everton", HomeScore: 0, AwayScore: 2, HomeXG: 2.4, AwayXG: 0.6,
+		HomeShots: shotsOf("on_target", "off_target"), AwayShots: shotsOf("goal", "goal")},
+	{Source: "understat", ID: 2, Gameweek: 2, Date: kickoff("2025-08-24 12:00"), HomeTeam: "Fulham", AwayTeam: "Arsenal", HomeScore: 1, AwayScore: 1, HomeXG: 1.2, AwayXG: 1.0,
+		HomeShots: shotsOf("goal"), AwayShots: shotsOf("goal")},
+}
+
+// saveFixtures saves copies of fixtures to repo
+func saveFixtures(t *testing.T, repo database.FixtureRepository, fixtures []domain.DBXGStatFixture) {
+	t.Helper()
+	for i := range fixtures {
+		fixture := fixtures[i]
+		if err := repo.SaveXGStatFixture(context.Background(), &fixture); err != nil {
+			t.Fatalf("SaveXGStatFixture returned error: %v", err)
+		}
+	}
+}
+
+func TestTeamSummary(t *testing.T) {
+	season := domain.TeamSummary{
+		Team: "Arsenal", Source: "xgstat", Matches: 3,
+		GoalsFor: 3, GoalsAgainst: 4, XGFor: 4.8, XGAgainst: 3.7, XGDifference: 1.1,
+		Shots: 8, ShotsOnTarget: 5, ShotsAgainst: 5, ShotsOnTargetAgainst: 5,
+		Per90: domain.TeamRates{GoalsFor: 1, GoalsAgainst: 1.33, XGFor: 1.6, XGAgainst: 1.23, Shots: 2.67, ShotsOnTarget: 1.67},
+	}
+
+	for name, open := range repositories {
+		t.Run(name, func(t *testing.T) {
+			repo := open(t)
+			saveFixtures(t, repo, arsenalFixtures)
+			ctx := context.Background()
+
+			got, err := repo.TeamSummary(ctx, "arsenal", domain.TeamFilter{Source: "xgstat"})
+			if err != nil {
+				t.Fatalf("TeamSummary returned error: %v", err)
+			}
+			if !reflect.DeepEqual(*got, season) {
+				t.Errorf("season summary:\n got %+v\nwant %+v", *got, season)
+			}
+
+			got, err = repo.TeamSummary(ctx, "Arsenal", domain.TeamFilter{Source: "xgstat", FromGameweek: 2, ToGameweek: 3})
+			if err != nil || got.Matches != 2 || got.GoalsFor != 1 || got.GoalsAgainst != 3 || got.Shots != 5 || got.XGDifference != 2.2 {
+				t.Errorf("gameweeks 2-3: %+v, %v", got, err)
+			}
+
+			got, err = repo.TeamSummary(ctx, "Arsenal", domain.TeamFilter{Source: "xgstat", Until: kickoff("2025-08-25 00:00")})
+			if err != nil || got.Matches != 2 || got.ShotsAgainst != 3 {
+				t.Errorf("until 25 August: %+v, %v", got, err)
+			}
+
+			// Without a source every copy of a match counts
+			got, err = repo.TeamSummary(ctx, "Fulham", domain.TeamFilter{})
+			if err != nil || got.Matches != 2 || got.XGFor != 2.1 || got.Per90.XGFor != 1.05 {
+				t.Errorf("Fulham from every source: %+v, %v", got, err)
+			}
+
+			for _, filter := range []domain.TeamFilter{
+				{Source: "xgstat", FromGameweek: 4},
+				{Source: "statsbomb"},
+			} {
+				if _, err := repo.TeamSummary(ctx, "Arsenal", filter); !errors.Is(err, database.ErrTeamNotFound) {
+					t.Errorf("%+v: %v, want ErrTeamNotFound", filter, err)
+				}
+			}
+			if _, err := repo.TeamSummary(ctx, "Liverpool", domain.TeamFilter{}); !errors.Is(err, database.ErrTeamNotFound) {
+				t.Errorf("unknown team: %v, want ErrTeamNotFound", err)
+			}
+		})
+	}
+}
+
+func TestTeamSummaryHandler(t *testing.T) {
+	repo := database.NewMemory()
+	saveFixtures(t, repo, arsenalFixtures)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/teams/{team}/summary", api.NewHandler(nil, nil, repo, nil).GetTeamSummary)
+	get := func(target string) (int, *domain.TeamSummary) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		var resp struct {
+			Data *domain.TeamSummary `json:"data"`
+		}
+		json.NewDecoder(rec.Body).Decode(&resp)
+		return rec.Code, resp.Data
+	}
+
+	// Fixtures default to the xgstat source; to includes the whole day
+	code, summary := get("/api/teams/arsenal/summary?from=2025-08-16&to=2025-08-24")
+	if code != http.StatusOK || summary == nil || summary.Matches != 2 || summary.Source != "xgstat" || summary.XGFor != 2.4 {
+		t.Errorf("date range: status %d, %+v", code, summary)
+	}
+	code, summary = get("/api/teams/Fulham/summary?source=understat")
+	if code != http.StatusOK || summary == nil || summary.Matches != 1 || summary.XGFor != 1.2 {
+		t.Errorf("understat: status %d, %+v", code, summary)
+	}
+	if code, _ := get("/api/teams/Manchester%20City/summary"); code != http.StatusNotFound {
+		t.Errorf("unknown team: status %d, want 404", code)
+	}
+	for _, target := range []string{
+		"/api/teams/Arsenal/summary?from=yesterday",
+		"/api/teams/Arsenal/summary?from_gameweek=x",
+		"/api/teams/Arsenal/summary?from_gameweek=5&to_gameweek=3",
+	} {
+		if code, _ := get(target); code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", target, code)
+		}
+	}
+}
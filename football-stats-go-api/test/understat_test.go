@@ -0,0 +1,159 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"example/hello/internal/domain"
+	"example/hello/internal/scraper"
+)
+
+// understatCase is a saved understat.com match page under testdata/understat
+var understatCase = struct {
+	name string
+	url  string
+}{
+	name: "arsenal-manchester-united-26631",
+	url:  "https://understat.com/match/26631",
+}
+
+func TestParseUnderstatGolden(t *testing.T) {
+	page, err := os.ReadFile(filepath.Join("testdata", "understat", understatCase.name+".html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+
+	got, report, err := scraper.ParsePage(string(page), understatCase.url)
+	if err != nil {
+		t.Fatalf("ParsePage returned error: %v", err)
+	}
+	if !report.Complete {
+		t.Errorf("parse report incomplete: missing %v, issues %v", report.FieldsMissing, report.Issues)
+	}
+	if !report.ShotDetail {
+		t.Error("Understat shots not reported as carrying player, minute and xG")
+	}
+
+	goldenPath := filepath.Join("testdata", "understat", understatCase.name+".golden.json")
+	if *update {
+		writeGolden(t, goldenPath, got)
+	}
+	want := readGolden(t, goldenPath)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fixture mismatch:\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestParseUnderstatShots(t *testing.T) {
+	page, err := os.ReadFile(filepath.Join("testdata", "understat", understatCase.name+".html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+
+	f, report, err := scraper.ParseUnderstatReport(string(page), understatCase.url)
+	if err != nil {
+		t.Fatalf("ParseUnderstatReport returned error: %v", err)
+	}
+
+	if f.Source != domain.SourceUnderstat || f.ID != 26631 || f.Gameweek != 0 {
+		t.Errorf("source, id, gameweek = %s, %d, %d", f.Source, f.ID, f.Gameweek)
+	}
+	if f.HomeTeam != "Arsenal" || f.AwayTeam != "Manchester United" || f.HomeScore != 2 || f.AwayScore != 1 {
+		t.Errorf("match = %s %d-%d %s", f.HomeTeam, f.HomeScore, f.AwayScore, f.AwayTeam)
+	}
+	if want := "2026-01-24T17:30:00Z"; f.Date.Format("2006-01-02T15:04:05Z07:00") != want {
+		t.Errorf("date = %s, want %s", f.Date, want)
+	}
+	// The own goal is not a shot but still counts towards Arsenal's score
+	if len(f.HomeShots) != 5 || len(f.AwayShots) != 2 {
+		t.Fatalf("got %d/%d shots, want 5/2", len(f.HomeShots), len(f.AwayShots))
+	}
+	if report.Home.Goals != 1 || report.Home.TableGoals != 1 {
+		t.Errorf("home goals = %d, want 1 plus the own goal", report.Home.Goals)
+	}
+	for _, field := range report.FieldsMissing {
+		if field == "gameweek" {
+			t.Errorf("gameweek reported missing for an Understat page")
+		}
+	}
+
+	penalty := f.HomeShots[1]
+	if penalty.PlayerName != "Bukayo Saka" || penalty.Minute != 23 || penalty.Situation != domain.SituationPenalty ||
+		!penalty.IsGoal || penalty.ShotType != "goal" || penalty.XG != 0.761 {
+		t.Errorf("penalty = %+v", penalty)
+	}
+	// Understat's X 0.885 is the penalty spot, 11 m from goal in the middle of the pitch
+	if penalty.X != 88.5 || penalty.Y != 50 || penalty.Distance < 12 || penalty.Distance > 12.2 || penalty.CoordinateSystem != "understat" {
+		t.Errorf("penalty position = %.3f, %.3f (%.2f m, %s)", penalty.X, penalty.Y, penalty.Distance, penalty.CoordinateSystem)
+	}
+
+	wantTypes := []string{"off_target", "goal", "on_target", "blocked", "off_target"}
+	wantSituations := []string{"open_play", "penalty", "corner", "open_play", "direct_free_kick"}
+	for i, shot := range f.HomeShots {
+		if shot.ShotType != wantTypes[i] || shot.Situation != wantSituations[i] {
+			t.Errorf("shot %d: type %s, situation %s; want %s, %s", i, shot.ShotType, shot.Situation, wantTypes[i], wantSituations[i])
+		}
+	}
+	if f.AwayShots[1].PlayerName != "Rasmus Højlund" {
+		t.Errorf("player name = %q, want the escaped name decoded", f.AwayShots[1].PlayerName)
+	}
+}
+
+func TestParseUnderstatMissingData(t *testing.T) {
+	page, err := os.ReadFile(filepath.Join("testdata", "understat", understatCase.name+".html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+	// A page whose shots never loaded still has its match info
+	withoutShots := strings.Replace(string(page), "var shotsData", "var shotsPending", 1)
+
+	_, report, err := scraper.ParseUnderstatReport(withoutShots, understatCase.url)
+	if err != nil {
+		t.Fatalf("ParseUnderstatReport returned error: %v", err)
+	}
+	if report.Complete || !reflect.DeepEqual(report.FieldsMissing, []string{"home_shot_map", "away_shot_map"}) {
+		t.Errorf("report = %+v, want both shot maps missing", report)
+	}
+}
+
+// TestParseUnderstatMinuteAfterExtraTime stores a shot past the shots
+// table's last minute as minute 120 and reports it
+func TestParseUnderstatMinuteAfterExtraTime(t *testing.T) {
+	page, err := os.ReadFile(filepath.Join("testdata", "understat", understatCase.name+".html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+	late := strings.Replace(string(page), `minute\x22\x3A\x2288\x22`, `minute\x22\x3A\x22123\x22`, 1)
+	if late == string(page) {
+		t.Fatal("test page no longer contains the expected 88th minute shot")
+	}
+
+	f, report, err := scraper.ParseUnderstatReport(late, understatCase.url)
+	if err != nil {
+		t.Fatalf("ParseUnderstatReport returned error: %v", err)
+	}
+	if got := f.AwayShots[1].Minute; got != 120 {
+		t.Errorf("minute = %d, want 120", got)
+	}
+	if report.Complete || len(report.Issues) != 1 || !strings.Contains(report.Issues[0], "minute 123") {
+		t.Errorf("issues = %v, want the clamped minute reported", report.Issues)
+	}
+}
+
+func TestIsUnderstatURL(t *testing.T) {
+	tests := map[string]bool{
+		"https://understat.com/match/26631":     true,
+		"https://www.understat.com/match/26631": true,
+		"https://understat.com/league/EPL":      false,
+		"https://www.xgstat.com/competitions/premier-league/2025-2026/matches/a-b-2026-01-24/advanced-analysis/shot-maps": false,
+	}
+	for url, want := range tests {
+		if got := scraper.IsUnderstatURL(url); got != want {
+			t.Errorf("IsUnderstatURL(%s) = %v, want %v", url, got, want)
+		}
+	}
+}
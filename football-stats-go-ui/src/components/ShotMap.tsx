@@ -13,9 +13,9 @@ const ShotMap: React.FC<ShotMapProps> = ({ homeShots, awayShots, homeTeam, awayT
   const pitchHeight = 400;
 
   const renderShot = (shot: XGStatShot, isHome: boolean, index: number) => {
-    // Convert coordinates to SVG coordinates
-    const x = (shot.x / 100) * pitchWidth;
-    const y = (shot.y / 100) * pitchHeight;
+    // Shots arrive attacking left to right; away shots are mirrored onto the left goal
+    const x = ((isHome ? shot.x : 100 - shot.x) / 100) * pitchWidth;
+    const y = ((isHome ? shot.y : 100 - shot.y) / 100) * pitchHeight;
     
     // Size based on xG value
     const radius = 4 + (shot.xg * 12);
@@ -1,8 +1,24 @@
 import React, { useState, FormEvent } from 'react';
-import { ApiResponse, XGStatFixture } from '../types';
+import { ApiResponse, ScrapeJob, XGStatFixture } from '../types';
 import ShotMap from './ShotMap';
 
 const API_URL = process.env.REACT_APP_API_URL || 'http://localhost:8080';
+const JOB_POLL_INTERVAL_MS = 2000;
+
+// Scrapes run as background jobs; poll until the job has finished
+async function waitForJob(jobId: string): Promise<ScrapeJob> {
+  for (;;) {
+    await new Promise(resolve => setTimeout(resolve, JOB_POLL_INTERVAL_MS));
+    const response = await fetch(`${API_URL}/api/scrape/jobs/${jobId}`);
+    const data: ApiResponse<ScrapeJob> = await response.json();
+    if (!response.ok || !data.success || !data.data) {
+      throw new Error(data.error || 'Failed to check scrape job');
+    }
+    if (data.data.status === 'succeeded' || data.data.status === 'failed') {
+      return data.data;
+    }
+  }
+}
 
 function XGStats() {
   const [url, setUrl] = useState<string>('');
@@ -26,15 +42,21 @@ function XGStats() {
         body: JSON.stringify({ url }),
       });
 
-      const data: ApiResponse<XGStatFixture> = await response.json();
+      const data: ApiResponse<ScrapeJob> = await response.json();
 
-      if (response.ok && data.success && data.data) {
+      if (!response.ok || !data.success || !data.data) {
+        setError(data.error || 'Failed to scrape fixture');
+        return;
+      }
+
+      const job = await waitForJob(data.data.id);
+      if (job.status === 'succeeded' && job.fixture) {
         // Add to fixtures list and set as current
-        setFixtures(prev => [data.data!, ...prev]);
+        setFixtures(prev => [job.fixture!, ...prev]);
         setCurrentFixtureIndex(0);
         setUrl('');
       } else {
-        setError(data.error || 'Failed to scrape fixture');
+        setError(job.error || 'Failed to scrape fixture');
       }
     } catch (err) {
       setError((err as Error).message || 'Network error');
@@ -6,11 +6,20 @@ export interface XGStatShot {
   shot_type: string;
   player_name: string;
   minute: number;
+  situation?: string;
+  body_part?: 'right_foot' | 'left_foot' | 'head' | 'other';
+  play_pattern?: string;
+  coordinate_system: string;
+  distance: number;
+  angle: number;
+  zone: string;
 }
 
 export interface XGStatFixture {
+  source: 'xgstat' | 'understat' | 'statsbomb';
   gameweek: number;
   id: number;
+  match_key?: string;
   date: string;
   home_team: string;
   away_team: string;
@@ -22,6 +31,101 @@ export interface XGStatFixture {
   away_shots: XGStatShot[];
 }
 
+export type FixtureSummary = Omit<XGStatFixture, 'home_shots' | 'away_shots' | 'match_key'> & { match_key: string };
+
+export interface FixturePage {
+  fixtures: FixtureSummary[];
+  next_cursor?: string;
+}
+
+export interface TeamRates {
+  goals_for: number;
+  goals_against: number;
+  xg_for: number;
+  xg_against: number;
+  shots: number;
+  shots_on_target: number;
+}
+
+export interface TeamSummary {
+  team: string;
+  source?: string;
+  matches: number;
+  goals_for: number;
+  goals_against: number;
+  xg_for: number;
+  xg_against: number;
+  xg_difference: number;
+  shots: number;
+  shots_on_target: number;
+  shots_against: number;
+  shots_on_target_against: number;
+  per_90: TeamRates;
+}
+
+export interface PlayerShot extends XGStatShot {
+  source: string;
+  fixture_id: number;
+  gameweek: number;
+  date: string;
+  team: string;
+  opponent: string;
+}
+
+export interface ShotTypeTotals {
+  shot_type: string;
+  shots: number;
+  goals: number;
+  xg: number;
+}
+
+export interface PlayerSummary {
+  player: string;
+  source?: string;
+  team?: string;
+  matches: number;
+  shots: number;
+  goals: number;
+  xg: number;
+  goals_minus_xg: number;
+  shots_per_match: number;
+  average_distance: number;
+  shot_types: ShotTypeTotals[];
+}
+
+export interface TableRow {
+  position: number;
+  team: string;
+  played: number;
+  won: number;
+  drawn: number;
+  lost: number;
+  goals_for: number;
+  goals_against: number;
+  goal_difference: number;
+  points: number;
+  xg_for: number;
+  xg_against: number;
+  xpts: number;
+  points_minus_xpts: number;
+  xpts_estimated: number;
+}
+
+export type ScrapeJobStatus = 'queued' | 'running' | 'succeeded' | 'failed';
+
+export interface ScrapeJob {
+  id: string;
+  url: string;
+  strict: boolean;
+  status: ScrapeJobStatus;
+  fixture?: XGStatFixture;
+  error?: string;
+  error_type?: 'not_found' | 'blocked' | 'timeout' | 'parse' | 'disallowed' | 'unsupported';
+  created_at: string;
+  started_at?: string;
+  finished_at?: string;
+}
+
 export interface MatchData {
   id: string;
   home_team: string;